@@ -0,0 +1,137 @@
+package directus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// AggregateResult represents one row of an aggregate query: the values of
+// the GroupBy fields and the aggregated values of that group
+type AggregateResult struct {
+	Group  map[string]interface{}                   // Values of the GroupBy fields, empty without GroupBy
+	Values map[AggregateFunc]map[string]interface{} // Aggregated values by function and field, "*" for counts of all items
+}
+
+// Aggregate computes the aggregate functions of params.Aggregate over the
+// items of a collection matching params, one result per group
+//
+//	results, err := client.Items.Aggregate(ctx, "orders", &directus.QueryParams{
+//		Aggregate: directus.Aggregate{directus.AggregateSum: {"total"}, directus.AggregateCount: nil},
+//		GroupBy:   []string{"status"},
+//	})
+func (s *ItemsService) Aggregate(ctx context.Context, collection string, params *QueryParams) ([]AggregateResult, error) {
+	if params == nil || len(params.Aggregate) == 0 {
+		return nil, fmt.Errorf("no aggregate functions given")
+	}
+
+	var resp struct {
+		Data []map[string]json.RawMessage `json:"data"`
+	}
+	if err := s.list(ctx, collection, params, &resp); err != nil {
+		return nil, err
+	}
+
+	results := make([]AggregateResult, len(resp.Data))
+	for i, row := range resp.Data {
+		result, err := parseAggregateRow(row, params.GroupBy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid aggregate result at index %d: %w", i, err)
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// parseAggregateRow splits a row of an aggregate response into group keys
+// and aggregated values
+func parseAggregateRow(row map[string]json.RawMessage, groupBy []string) (AggregateResult, error) {
+	result := AggregateResult{
+		Group:  make(map[string]interface{}),
+		Values: make(map[AggregateFunc]map[string]interface{}),
+	}
+
+	for key, raw := range row {
+		var value interface{}
+		if err := unmarshalNumber(raw, &value); err != nil {
+			return result, err
+		}
+
+		fields, isObject := value.(map[string]interface{})
+		if slices.Contains(groupBy, key) || !isAggregateFunc(key) {
+			result.Group[key] = value
+			continue
+		}
+
+		if !isObject {
+			// count(*) and countAll come back as a plain number
+			fields = map[string]interface{}{"*": value}
+		}
+		result.Values[AggregateFunc(key)] = fields
+	}
+
+	return result, nil
+}
+
+// isAggregateFunc reports whether name is an aggregate function
+func isAggregateFunc(name string) bool {
+	switch AggregateFunc(name) {
+	case AggregateCount, AggregateCountDistinct, AggregateCountAll,
+		AggregateSum, AggregateSumDistinct, AggregateAvg, AggregateAvgDistinct,
+		AggregateMin, AggregateMax:
+		return true
+	}
+	return false
+}
+
+// Value returns the aggregated value of a function over field, nil if absent
+func (r AggregateResult) Value(fn AggregateFunc, field string) interface{} {
+	return r.Values[fn][field]
+}
+
+// Float returns the aggregated value of a function over field as a number.
+// Databases return some aggregates, like sums of decimals, as strings.
+func (r AggregateResult) Float(fn AggregateFunc, field string) (float64, bool) {
+	switch v := r.Value(fn, field).(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// Int returns the aggregated value of a function over field as an integer
+func (r AggregateResult) Int(fn AggregateFunc, field string) (int64, bool) {
+	switch v := r.Value(fn, field).(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i, true
+		}
+	}
+
+	f, ok := r.Float(fn, field)
+	if !ok || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// Count returns the number of items in the group, as computed by Count or
+// CountAll without fields
+func (r AggregateResult) Count() (int64, bool) {
+	if count, ok := r.Int(AggregateCount, "*"); ok {
+		return count, true
+	}
+	return r.Int(AggregateCountAll, "*")
+}
@@ -0,0 +1,188 @@
+package directus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// aggregateRow decodes a row of an aggregate response
+func aggregateRow(t *testing.T, data string) map[string]json.RawMessage {
+	t.Helper()
+	var row map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &row); err != nil {
+		t.Fatal(err)
+	}
+	return row
+}
+
+func TestParseAggregateRow(t *testing.T) {
+	tests := []struct {
+		name       string
+		row        string
+		groupBy    []string
+		wantGroup  map[string]interface{}
+		wantValues map[AggregateFunc]map[string]interface{}
+		wantCount  int64
+		hasCount   bool
+	}{
+		{
+			name:      "plain count",
+			row:       `{"count":12}`,
+			wantGroup: map[string]interface{}{},
+			wantValues: map[AggregateFunc]map[string]interface{}{
+				AggregateCount: {"*": json.Number("12")},
+			},
+			wantCount: 12, hasCount: true,
+		},
+		{
+			name:      "count object",
+			row:       `{"count":{"*":"7"}}`,
+			wantGroup: map[string]interface{}{},
+			wantValues: map[AggregateFunc]map[string]interface{}{
+				AggregateCount: {"*": "7"},
+			},
+			wantCount: 7, hasCount: true,
+		},
+		{
+			name:      "countAll",
+			row:       `{"countAll":3}`,
+			wantGroup: map[string]interface{}{},
+			wantValues: map[AggregateFunc]map[string]interface{}{
+				AggregateCountAll: {"*": json.Number("3")},
+			},
+			wantCount: 3, hasCount: true,
+		},
+		{
+			name:      "count of fields",
+			row:       `{"count":{"id":4,"email":3}}`,
+			wantGroup: map[string]interface{}{},
+			wantValues: map[AggregateFunc]map[string]interface{}{
+				AggregateCount: {"id": json.Number("4"), "email": json.Number("3")},
+			},
+		},
+		{
+			name:    "grouped",
+			row:     `{"status":"paid","country":null,"sum":{"total":"120.50"},"avg":{"total":40.1666},"count":3}`,
+			groupBy: []string{"status", "country"},
+			wantGroup: map[string]interface{}{
+				"status":  "paid",
+				"country": nil,
+			},
+			wantValues: map[AggregateFunc]map[string]interface{}{
+				AggregateSum:   {"total": "120.50"},
+				AggregateAvg:   {"total": json.Number("40.1666")},
+				AggregateCount: {"*": json.Number("3")},
+			},
+			wantCount: 3, hasCount: true,
+		},
+		{
+			name:    "group field named like a function",
+			row:     `{"max":5,"min":{"price":1}}`,
+			groupBy: []string{"max"},
+			wantGroup: map[string]interface{}{
+				"max": json.Number("5"),
+			},
+			wantValues: map[AggregateFunc]map[string]interface{}{
+				AggregateMin: {"price": json.Number("1")},
+			},
+		},
+		{
+			name:    "function field group",
+			row:     `{"date_created_year":2024,"sum":{"total":10}}`,
+			groupBy: []string{Year("date_created")},
+			wantGroup: map[string]interface{}{
+				"date_created_year": json.Number("2024"),
+			},
+			wantValues: map[AggregateFunc]map[string]interface{}{
+				AggregateSum: {"total": json.Number("10")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseAggregateRow(aggregateRow(t, tt.row), tt.groupBy)
+			if err != nil {
+				t.Fatalf("parseAggregateRow: %v", err)
+			}
+			if !reflect.DeepEqual(result.Group, tt.wantGroup) {
+				t.Errorf("Group = %v, want %v", result.Group, tt.wantGroup)
+			}
+			if !reflect.DeepEqual(result.Values, tt.wantValues) {
+				t.Errorf("Values = %v, want %v", result.Values, tt.wantValues)
+			}
+			if count, ok := result.Count(); count != tt.wantCount || ok != tt.hasCount {
+				t.Errorf("Count = %d, %v, want %d, %v", count, ok, tt.wantCount, tt.hasCount)
+			}
+		})
+	}
+}
+
+func TestAggregateResultNumbers(t *testing.T) {
+	result, err := parseAggregateRow(aggregateRow(t, `{"sum":{"total":"120.50","qty":"7"},"avg":{"total":2.5},"max":{"qty":9,"name":"z"}}`), nil)
+	if err != nil {
+		t.Fatalf("parseAggregateRow: %v", err)
+	}
+
+	if f, ok := result.Float(AggregateSum, "total"); !ok || f != 120.5 {
+		t.Errorf("Float(sum, total) = %v, %v, want 120.5", f, ok)
+	}
+	if _, ok := result.Int(AggregateSum, "total"); ok {
+		t.Error("Int(sum, total) ok, want false for a decimal")
+	}
+	if i, ok := result.Int(AggregateSum, "qty"); !ok || i != 7 {
+		t.Errorf("Int(sum, qty) = %v, %v, want 7", i, ok)
+	}
+	if f, ok := result.Float(AggregateAvg, "total"); !ok || f != 2.5 {
+		t.Errorf("Float(avg, total) = %v, %v, want 2.5", f, ok)
+	}
+	if i, ok := result.Int(AggregateMax, "qty"); !ok || i != 9 {
+		t.Errorf("Int(max, qty) = %v, %v, want 9", i, ok)
+	}
+	if _, ok := result.Float(AggregateMax, "name"); ok {
+		t.Error("Float(max, name) ok, want false for text")
+	}
+	if v := result.Value(AggregateMin, "qty"); v != nil {
+		t.Errorf("Value(min, qty) = %v, want nil", v)
+	}
+	if _, ok := result.Count(); ok {
+		t.Error("Count ok without a count")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":[{"status":"paid","sum":{"total":"30.00"},"count":2},{"status":"open","sum":{"total":null},"count":0}]}`))
+	})
+	client := server.client(t, Config{})
+
+	results, err := client.Items.Aggregate(context.Background(), "orders", &QueryParams{
+		Aggregate: Aggregate{AggregateSum: {"total"}, AggregateCount: nil},
+		GroupBy:   []string{"status"},
+	})
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if len(results) != 2 || results[0].Group["status"] != "paid" || results[1].Group["status"] != "open" {
+		t.Fatalf("results = %+v, want the paid and open groups", results)
+	}
+	if count, _ := results[0].Count(); count != 2 {
+		t.Errorf("paid count = %d, want 2", count)
+	}
+	if v := results[1].Value(AggregateSum, "total"); v != nil {
+		t.Errorf("open sum = %v, want nil", v)
+	}
+
+	query := server.recorded()[0].Query
+	if query.Get("aggregate[sum]") != "total" || query.Get("aggregate[count]") != "*" || query.Get("groupBy") != "status" {
+		t.Errorf("query = %v, want aggregate[sum]=total, aggregate[count]=* and groupBy=status", query)
+	}
+
+	if _, err := client.Items.Aggregate(context.Background(), "orders", &QueryParams{}); err == nil || !strings.Contains(err.Error(), "no aggregate functions") {
+		t.Errorf("Aggregate without functions error = %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package directus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// defaultRefreshLeeway is how long before expiry an access token is refreshed
+const defaultRefreshLeeway = 30 * time.Second
+
+// authData represents the token payload returned by /auth/login and /auth/refresh
+type authData struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	Expires      int64  `json:"expires"` // Lifetime of the access token in milliseconds
+}
+
+// skipAuthKey marks requests that must be sent without an access token
+type skipAuthKey struct{}
+
+// withoutAuth returns a context for requests that must not carry an access token
+func withoutAuth(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipAuthKey{}, true)
+}
+
+// session is the TokenSource of clients configured with a token or with
+// email/password. It keeps the refresh token returned by /auth/login and
+// refreshes the access token before it expires. It is safe for concurrent use.
+type session struct {
+	httpClient     *resty.Client
+	email          string
+	password       string
+	leeway         time.Duration
+	onRefreshError func(error)
+
+	mu           sync.RWMutex
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+
+	// refreshMu serializes refreshes so concurrent callers share one round trip
+	refreshMu sync.Mutex
+}
+
+// set stores the tokens returned by the auth endpoints
+func (s *session) set(data authData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessToken = data.AccessToken
+	s.refreshToken = data.RefreshToken
+	s.expiresAt = time.Time{}
+	if data.Expires > 0 {
+		s.expiresAt = time.Now().Add(time.Duration(data.Expires) * time.Millisecond)
+	}
+}
+
+// clear forgets all tokens
+func (s *session) clear() {
+	s.set(authData{})
+}
+
+// current returns the token currently held by the session
+func (s *session) current() *Token {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &Token{
+		AccessToken:  s.accessToken,
+		RefreshToken: s.refreshToken,
+		Expiry:       s.expiresAt,
+	}
+}
+
+// canRefresh reports whether the session is able to obtain a new access token
+func (s *session) canRefresh() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.refreshToken != "" || (s.email != "" && s.password != "")
+}
+
+// Token returns a valid access token, refreshing it first if it is about to expire
+func (s *session) Token(ctx context.Context) (*Token, error) {
+	token := s.current()
+	if !token.expiresWithin(s.leeway) || !s.canRefresh() {
+		return token, nil
+	}
+
+	fresh, err := s.Refresh(ctx, token)
+	if err != nil {
+		// Keep using the old token while it is still valid; the server
+		// will answer 401 once it is not and the request is retried.
+		if token.Valid() {
+			return token, nil
+		}
+		return nil, err
+	}
+	return fresh, nil
+}
+
+// Refresh exchanges the refresh token for a new access token. If another
+// goroutine already replaced the rejected token, the newer token is returned
+// without another round trip.
+func (s *session) Refresh(ctx context.Context, rejected *Token) (*Token, error) {
+	if !s.canRefresh() {
+		return nil, fmt.Errorf("session has no refresh token or credentials")
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	current := s.current()
+	if rejected != nil && current.AccessToken != rejected.AccessToken {
+		return current, nil
+	}
+
+	var (
+		data authData
+		err  error
+	)
+	if current.RefreshToken != "" {
+		data, err = refreshSession(ctx, s.httpClient, current.RefreshToken)
+	}
+	if (current.RefreshToken == "" || err != nil) && s.email != "" && s.password != "" {
+		// The refresh token is gone or expired, start a new session
+		data, err = authenticate(ctx, s.httpClient, s.email, s.password)
+	}
+	if err != nil {
+		if s.onRefreshError != nil {
+			s.onRefreshError(err)
+		}
+		return nil, fmt.Errorf("token refresh failed: %w", err)
+	}
+
+	s.set(data)
+	return s.current(), nil
+}
+
+// Refresh forces the client to obtain a new access token from its token source
+func (c *Client) Refresh(ctx context.Context) error {
+	src, ok := c.tokenSource().(RefreshableTokenSource)
+	if !ok {
+		return fmt.Errorf("token source cannot refresh tokens")
+	}
+	_, err := src.Refresh(ctx, nil)
+	return err
+}
+
+// Logout invalidates the refresh token on the server and clears the session
+func (c *Client) Logout(ctx context.Context) error {
+	c.session.mu.RLock()
+	refreshToken := c.session.refreshToken
+	c.session.mu.RUnlock()
+
+	if refreshToken == "" {
+		return fmt.Errorf("client has no active session")
+	}
+
+	response, err := c.request(withoutAuth(ctx), Operation{Service: "auth", Name: "logout"}).
+		SetBody(map[string]string{
+			"refresh_token": refreshToken,
+		}).
+		Post("/auth/logout")
+
+	if err != nil {
+		return err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
+	}
+
+	c.session.clear()
+	return nil
+}
+
+// authenticate performs authentication with email/password
+func authenticate(ctx context.Context, client *resty.Client, email, password string) (authData, error) {
+	var resp struct {
+		Data authData `json:"data"`
+	}
+
+	response, err := client.R().
+		SetContext(withOperation(withoutAuth(ctx), Operation{Service: "auth", Name: "login"})).
+		SetBody(map[string]string{
+			"email":    email,
+			"password": password,
+		}).
+		SetResult(&resp).
+		Post("/auth/login")
+
+	if err != nil {
+		return authData{}, err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return authData{}, parseError(response)
+	}
+
+	return resp.Data, nil
+}
+
+// refreshSession exchanges a refresh token for a new token pair
+func refreshSession(ctx context.Context, client *resty.Client, refreshToken string) (authData, error) {
+	var resp struct {
+		Data authData `json:"data"`
+	}
+
+	response, err := client.R().
+		SetContext(withOperation(withoutAuth(ctx), Operation{Service: "auth", Name: "refresh"})).
+		SetBody(map[string]string{
+			"refresh_token": refreshToken,
+			"mode":          "json",
+		}).
+		SetResult(&resp).
+		Post("/auth/refresh")
+
+	if err != nil {
+		return authData{}, err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return authData{}, parseError(response)
+	}
+
+	return resp.Data, nil
+}
+
+// authTransport attaches a token from the client's token source to every
+// request and retries a request once with a refreshed token when the server
+// answers 401
+type authTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if skip, _ := ctx.Value(skipAuthKey{}).(bool); skip {
+		return t.base.RoundTrip(req)
+	}
+
+	src := t.client.tokenSource()
+	if src == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := src.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	resp, err := t.base.RoundTrip(authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	refresher, ok := src.(RefreshableTokenSource)
+	if !ok {
+		return resp, nil
+	}
+
+	// The body has been consumed by the first attempt and cannot be replayed
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	// Release the response before refreshing: closing its body frees the
+	// concurrency slot it holds, which the refresh request may need
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fresh, err := refresher.Refresh(ctx, token)
+	if err != nil || !fresh.Valid() || fresh.AccessToken == token.AccessToken {
+		return resp, nil
+	}
+
+	retry := authorize(req, fresh)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+
+	return t.base.RoundTrip(retry)
+}
+
+// authorize returns a copy of req carrying the given access token
+func authorize(req *http.Request, token *Token) *http.Request {
+	r := req.Clone(req.Context())
+	if token != nil && token.AccessToken != "" {
+		r.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	}
+	return r
+}
@@ -0,0 +1,274 @@
+package directus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// authServer is a Directus stand-in that issues tokens on /auth/login and
+// /auth/refresh and accepts only the latest access token elsewhere
+type authServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	issued        int
+	access        string
+	refresh       string
+	loginExpires  int64 // Lifetime of tokens from /auth/login, in milliseconds
+	rejectRefresh bool
+	rejectLogin   bool
+	gate          int // Unauthorized requests are held until this many arrived
+	unauthorized  int
+	logins        int
+	refreshes     int
+	logouts       int
+	bodies        []string // Bodies of authorized requests
+	arrived       chan struct{}
+}
+
+func newAuthServer(t *testing.T) *authServer {
+	t.Helper()
+	s := &authServer{loginExpires: 900000, arrived: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *authServer) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	s.mu.Lock()
+	switch r.URL.Path {
+	case "/auth/login":
+		s.logins++
+		if s.rejectLogin {
+			s.mu.Unlock()
+			writeAuthError(w, "INVALID_CREDENTIALS")
+			return
+		}
+		s.issue(w, s.loginExpires)
+	case "/auth/refresh":
+		s.refreshes++
+		var payload struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		json.Unmarshal(body, &payload)
+		if s.rejectRefresh || payload.RefreshToken != s.refresh {
+			s.mu.Unlock()
+			writeAuthError(w, "INVALID_CREDENTIALS")
+			return
+		}
+		s.issue(w, 900000)
+	case "/auth/logout":
+		s.logouts++
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		if r.Header.Get("Authorization") != "Bearer "+s.access {
+			s.unauthorized++
+			if s.unauthorized == s.gate {
+				close(s.arrived)
+			}
+			held := s.unauthorized <= s.gate
+			s.mu.Unlock()
+			if held {
+				select {
+				case <-s.arrived:
+				case <-time.After(5 * time.Second):
+				}
+			}
+			writeAuthError(w, "TOKEN_EXPIRED")
+			return
+		}
+		s.bodies = append(s.bodies, string(body))
+		fmt.Fprint(w, `{"data":{"id":1}}`)
+	}
+	s.mu.Unlock()
+}
+
+// issue writes a new token pair
+func (s *authServer) issue(w http.ResponseWriter, expires int64) {
+	s.issued++
+	s.access = fmt.Sprintf("access-%d", s.issued)
+	s.refresh = fmt.Sprintf("refresh-%d", s.issued)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": authData{AccessToken: s.access, RefreshToken: s.refresh, Expires: expires},
+	})
+}
+
+// revoke makes the server reject the access token the client holds, and
+// holds the next n unauthorized requests until all of them arrived
+func (s *authServer) revoke(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.access = "revoked"
+	s.gate = n
+	s.unauthorized = 0
+	s.arrived = make(chan struct{})
+}
+
+// counts returns the number of logins and refreshes
+func (s *authServer) counts() (logins, refreshes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logins, s.refreshes
+}
+
+func writeAuthError(w http.ResponseWriter, code string) {
+	w.WriteHeader(http.StatusUnauthorized)
+	fmt.Fprintf(w, `{"errors":[{"message":"Unauthorized","extensions":{"code":%q}}]}`, code)
+}
+
+// newSessionClient creates a client logged in to s with email and password
+func newSessionClient(t *testing.T, s *authServer, config Config) *Client {
+	t.Helper()
+	config.BaseURL = s.URL
+	config.Email = "admin@example.com"
+	config.Password = "secret"
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+// getConcurrently gets an item from n goroutines and returns their errors
+func getConcurrently(ctx context.Context, client *Client, n int) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = client.Items.Get(ctx, "articles", "1", nil)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestRefreshWithConcurrencyLimit(t *testing.T) {
+	for _, n := range []int{1, 3} {
+		t.Run(fmt.Sprintf("MaxConcurrent=%d", n), func(t *testing.T) {
+			server := newAuthServer(t)
+			client := newSessionClient(t, server, Config{RateLimit: &RateLimit{MaxConcurrent: n}})
+			server.revoke(n)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			for i, err := range getConcurrently(ctx, client, n) {
+				if err != nil {
+					t.Errorf("request %d: %v", i, err)
+				}
+			}
+			if _, refreshes := server.counts(); refreshes != 1 {
+				t.Errorf("refreshes = %d, want 1", refreshes)
+			}
+		})
+	}
+}
+
+func TestConcurrentUnauthorizedRefreshOnce(t *testing.T) {
+	server := newAuthServer(t)
+	client := newSessionClient(t, server, Config{})
+	server.revoke(10)
+
+	for i, err := range getConcurrently(context.Background(), client, 10) {
+		if err != nil {
+			t.Errorf("request %d: %v", i, err)
+		}
+	}
+	if logins, refreshes := server.counts(); logins != 1 || refreshes != 1 {
+		t.Errorf("logins, refreshes = %d, %d, want 1, 1", logins, refreshes)
+	}
+}
+
+func TestProactiveRefresh(t *testing.T) {
+	server := newAuthServer(t)
+	server.loginExpires = 1000 // Within the default leeway
+	client := newSessionClient(t, server, Config{})
+
+	for i, err := range getConcurrently(context.Background(), client, 5) {
+		if err != nil {
+			t.Errorf("request %d: %v", i, err)
+		}
+	}
+	if _, refreshes := server.counts(); refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", refreshes)
+	}
+	if server.unauthorized != 0 {
+		t.Errorf("unauthorized requests = %d, want 0", server.unauthorized)
+	}
+}
+
+func TestRefreshFallsBackToLogin(t *testing.T) {
+	server := newAuthServer(t)
+	client := newSessionClient(t, server, Config{})
+	server.revoke(0)
+	server.rejectRefresh = true
+
+	if _, err := client.Items.Get(context.Background(), "articles", "1", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if logins, refreshes := server.counts(); logins != 2 || refreshes != 1 {
+		t.Errorf("logins, refreshes = %d, %d, want 2, 1", logins, refreshes)
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	server := newAuthServer(t)
+	client := newSessionClient(t, server, Config{})
+	server.revoke(0)
+
+	if _, err := client.Items.Update(context.Background(), "articles", "1", Item{"title": "Retried"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if len(server.bodies) != 1 || server.bodies[0] != `{"title":"Retried"}` {
+		t.Errorf("bodies = %q, want the update body once", server.bodies)
+	}
+}
+
+func TestOnRefreshError(t *testing.T) {
+	var refreshErrs []error
+	server := newAuthServer(t)
+	client := newSessionClient(t, server, Config{
+		OnRefreshError: func(err error) { refreshErrs = append(refreshErrs, err) },
+	})
+	server.revoke(0)
+	server.rejectRefresh = true
+	server.rejectLogin = true
+
+	_, err := client.Items.Get(context.Background(), "articles", "1", nil)
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Get error = %v, want ErrTokenExpired", err)
+	}
+	if len(refreshErrs) != 1 || !errors.Is(refreshErrs[0], ErrInvalidCredentials) {
+		t.Errorf("refresh errors = %v, want one ErrInvalidCredentials", refreshErrs)
+	}
+}
+
+func TestLogoutClearsSession(t *testing.T) {
+	server := newAuthServer(t)
+	client := newSessionClient(t, server, Config{})
+
+	if err := client.Logout(context.Background()); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+	if server.logouts != 1 {
+		t.Errorf("logouts = %d, want 1", server.logouts)
+	}
+	if token := client.GetToken(); token != "" {
+		t.Errorf("token after logout = %q, want none", token)
+	}
+	if err := client.Logout(context.Background()); err == nil {
+		t.Error("second Logout succeeded, want an error")
+	}
+}
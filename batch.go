@@ -0,0 +1,316 @@
+package directus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultChunkSize is the number of items or keys sent per batch request
+const defaultChunkSize = 100
+
+// ErrEmptyFilter is returned by queries that would affect every item of a
+// collection because their filter is empty
+var ErrEmptyFilter = errors.New("directus: empty filter matches every item")
+
+// BatchOptions configures how batch operations split their input
+type BatchOptions struct {
+	ChunkSize       int  // Items or keys per request. Defaults to 100
+	ContinueOnError bool // Send the remaining chunks after a chunk fails
+}
+
+// ChunkError reports a chunk of a batch operation that failed
+type ChunkError struct {
+	Chunk  int   // Index of the chunk
+	Offset int   // Index in the input of the chunk's first element
+	Size   int   // Number of elements in the chunk
+	Err    error // Why the chunk failed
+}
+
+// Error implements the error interface
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (elements %d-%d): %v", e.Chunk, e.Offset, e.Offset+e.Size-1, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError reports the chunks of a batch operation that failed. The
+// results of the other chunks are returned alongside it.
+type BatchError struct {
+	Chunks []*ChunkError
+}
+
+// Error implements the error interface
+func (e *BatchError) Error() string {
+	messages := make([]string, len(e.Chunks))
+	for i, chunk := range e.Chunks {
+		messages[i] = chunk.Error()
+	}
+	return fmt.Sprintf("%d chunk(s) failed: %s", len(e.Chunks), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the chunk errors, so errors.Is and errors.As see the
+// API errors behind them
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i, chunk := range e.Chunks {
+		errs[i] = chunk
+	}
+	return errs
+}
+
+// Keys converts a slice of primary keys for use with batch operations
+func Keys[K string | int | int64](keys []K) []interface{} {
+	converted := make([]interface{}, len(keys))
+	for i, key := range keys {
+		converted[i] = key
+	}
+	return converted
+}
+
+// runChunks calls fn for consecutive ranges of n elements and collects the
+// chunks that failed into a *BatchError
+func runChunks(ctx context.Context, n int, opts *BatchOptions, fn func(start, end int) error) error {
+	size := defaultChunkSize
+	continueOnError := false
+	if opts != nil {
+		if opts.ChunkSize > 0 {
+			size = opts.ChunkSize
+		}
+		continueOnError = opts.ContinueOnError
+	}
+
+	var failed []*ChunkError
+	for chunk, start := 0, 0; start < n; chunk, start = chunk+1, start+size {
+		end := min(start+size, n)
+
+		err := ctx.Err()
+		if err == nil {
+			err = fn(start, end)
+		}
+		if err != nil {
+			failed = append(failed, &ChunkError{Chunk: chunk, Offset: start, Size: end - start, Err: err})
+			if !continueOnError || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return &BatchError{Chunks: failed}
+	}
+	return nil
+}
+
+// CreateMany creates items in a collection, posting them in chunks. It
+// returns the created items of all chunks that succeeded and, if any
+// failed, a *BatchError.
+func (s *ItemsService) CreateMany(ctx context.Context, collection string, items []Item, opts *BatchOptions) ([]Item, error) {
+	var created []Item
+	err := runChunks(ctx, len(items), opts, func(start, end int) error {
+		result, err := s.batch(ctx, "create_many", collection, http.MethodPost, items[start:end])
+		created = append(created, result...)
+		return err
+	})
+	return created, err
+}
+
+// UpdateMany applies the same changes to the items with the given primary
+// keys, patching them in chunks. It returns the updated items of all chunks
+// that succeeded and, if any failed, a *BatchError.
+func (s *ItemsService) UpdateMany(ctx context.Context, collection string, keys []interface{}, data Item, opts *BatchOptions) ([]Item, error) {
+	var updated []Item
+	err := runChunks(ctx, len(keys), opts, func(start, end int) error {
+		body := map[string]interface{}{"keys": keys[start:end], "data": data}
+		result, err := s.batch(ctx, "update_many", collection, http.MethodPatch, body)
+		updated = append(updated, result...)
+		return err
+	})
+	return updated, err
+}
+
+// UpdateByQuery applies the same changes to every item matching filter in
+// a single request, and returns the updated items. An empty filter returns
+// ErrEmptyFilter.
+func (s *ItemsService) UpdateByQuery(ctx context.Context, collection string, filter map[string]interface{}, data Item) ([]Item, error) {
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("update by query on %s: %w", collection, ErrEmptyFilter)
+	}
+	body := map[string]interface{}{
+		// Without a limit Directus only updates the first page of matches
+		"query": map[string]interface{}{"filter": filter, "limit": -1},
+		"data":  data,
+	}
+	return s.batch(ctx, "update_by_query", collection, http.MethodPatch, body)
+}
+
+// UpdateBatch applies different changes to several items, each of which
+// must contain its primary key, patching them in chunks. It returns the
+// updated items of all chunks that succeeded and, if any failed, a
+// *BatchError.
+func (s *ItemsService) UpdateBatch(ctx context.Context, collection string, items []Item, opts *BatchOptions) ([]Item, error) {
+	var updated []Item
+	err := runChunks(ctx, len(items), opts, func(start, end int) error {
+		result, err := s.batch(ctx, "update_batch", collection, http.MethodPatch, items[start:end])
+		updated = append(updated, result...)
+		return err
+	})
+	return updated, err
+}
+
+// DeleteOptions configures bulk deletes
+type DeleteOptions struct {
+	BatchOptions
+	DryRun     bool   // Only return the keys that would be deleted
+	PrimaryKey string // Primary key field of the collection, used by DryRun. Defaults to "id"
+}
+
+// DeleteMany deletes the items with the given primary keys in chunks. It
+// returns the keys of all chunks that were deleted and, if any chunk failed,
+// a *BatchError. With DryRun nothing is deleted, and the keys that exist are
+// returned instead.
+func (s *ItemsService) DeleteMany(ctx context.Context, collection string, keys []interface{}, opts *DeleteOptions) ([]interface{}, error) {
+	if opts == nil {
+		opts = &DeleteOptions{}
+	}
+
+	var deleted []interface{}
+	err := runChunks(ctx, len(keys), &opts.BatchOptions, func(start, end int) error {
+		chunk := keys[start:end]
+		if opts.DryRun {
+			filter := map[string]interface{}{opts.primaryKey(): map[string]interface{}{string(FilterIn): chunk}}
+			existing, err := s.keys(ctx, collection, filter, opts)
+			deleted = append(deleted, existing...)
+			return err
+		}
+
+		if err := s.delete(ctx, "delete_many", collection, map[string]interface{}{"keys": chunk}); err != nil {
+			return err
+		}
+		deleted = append(deleted, chunk...)
+		return nil
+	})
+	return deleted, err
+}
+
+// DeleteByQuery deletes every item matching filter in a single request.
+// With DryRun nothing is deleted, and the keys of the matching items are
+// returned instead; otherwise the returned keys are nil. An empty filter
+// returns ErrEmptyFilter; use DeleteAll to empty a collection.
+func (s *ItemsService) DeleteByQuery(ctx context.Context, collection string, filter map[string]interface{}, opts *DeleteOptions) ([]interface{}, error) {
+	if len(filter) == 0 {
+		return nil, fmt.Errorf("delete by query on %s: %w", collection, ErrEmptyFilter)
+	}
+	if opts == nil {
+		opts = &DeleteOptions{}
+	}
+
+	if opts.DryRun {
+		return s.keys(ctx, collection, filter, opts)
+	}
+
+	body := map[string]interface{}{
+		// Without a limit Directus only deletes the first page of matches
+		"query": map[string]interface{}{"filter": filter, "limit": -1},
+	}
+	return nil, s.delete(ctx, "delete_by_query", collection, body)
+}
+
+// DeleteAll deletes every item of a collection in a single request
+func (s *ItemsService) DeleteAll(ctx context.Context, collection string) error {
+	body := map[string]interface{}{
+		"query": map[string]interface{}{"limit": -1},
+	}
+	return s.delete(ctx, "delete_all", collection, body)
+}
+
+// primaryKey returns the primary key field of the collection
+func (o *DeleteOptions) primaryKey() string {
+	if o.PrimaryKey != "" {
+		return o.PrimaryKey
+	}
+	return "id"
+}
+
+// keys returns the primary keys of all items matching filter
+func (s *ItemsService) keys(ctx context.Context, collection string, filter map[string]interface{}, opts *DeleteOptions) ([]interface{}, error) {
+	pk := opts.primaryKey()
+	params := &QueryParams{Fields: []string{pk}, Filter: filter}
+	pager := s.Pager(ctx, collection, params, &PageOptions{PageSize: opts.ChunkSize, KeyField: pk})
+
+	var keys []interface{}
+	for pager.Next() {
+		keys = append(keys, pager.Item()[pk])
+	}
+	return keys, pager.Err()
+}
+
+// delete sends a bulk delete with body to the items endpoint of a collection
+func (s *ItemsService) delete(ctx context.Context, name, collection string, body interface{}) error {
+	path := fmt.Sprintf("/items/%s", collection)
+
+	response, err := s.client.request(ctx, Operation{Service: "items", Name: name, Collection: collection}).
+		SetBody(body).
+		Delete(path)
+
+	if err != nil {
+		return err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
+	}
+
+	return nil
+}
+
+// batch sends body to the items endpoint of a collection and returns the
+// items in the response
+func (s *ItemsService) batch(ctx context.Context, name, collection, method string, body interface{}) ([]Item, error) {
+	var resp Response
+	path := fmt.Sprintf("/items/%s", collection)
+
+	response, err := s.client.request(ctx, Operation{Service: "items", Name: name, Collection: collection}).
+		SetBody(body).
+		SetResult(&resp).
+		Execute(method, path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Directus answers 204 when the items cannot be read back
+	if response.StatusCode() == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if err := parseResponse(response, &resp); err != nil {
+		return nil, err
+	}
+
+	return toItems(resp.Data)
+}
+
+// toItems converts the data of a response to items
+func toItems(data interface{}) ([]Item, error) {
+	values, ok := data.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid response format: expected array, got %T", data)
+	}
+
+	items := make([]Item, len(values))
+	for i, v := range values {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid item format at index %d: expected object, got %T", i, v)
+		}
+		items[i] = Item(item)
+	}
+
+	return items, nil
+}
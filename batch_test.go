@@ -0,0 +1,59 @@
+package directus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDeleteByQueryRejectsEmptyFilter(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := server.client(t, Config{})
+
+	for _, filter := range []map[string]interface{}{nil, {}} {
+		for _, opts := range []*DeleteOptions{nil, {DryRun: true}} {
+			if _, err := client.Items.DeleteByQuery(context.Background(), "logs", filter, opts); !errors.Is(err, ErrEmptyFilter) {
+				t.Errorf("DeleteByQuery(%v, %+v) error = %v, want ErrEmptyFilter", filter, opts, err)
+			}
+		}
+	}
+	if requests := server.recorded(); len(requests) != 0 {
+		t.Fatalf("sent %d requests, want none", len(requests))
+	}
+
+	if err := client.Items.DeleteAll(context.Background(), "logs"); err != nil {
+		t.Fatalf("DeleteAll: %v", err)
+	}
+	requests := server.recorded()
+	if len(requests) != 1 || requests[0].Method != http.MethodDelete || requests[0].Body != `{"query":{"limit":-1}}` {
+		t.Errorf("DeleteAll sent %+v, want a query without filter", requests)
+	}
+}
+
+func TestUpdateByQueryRejectsEmptyFilter(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []interface{}{}})
+	})
+	client := server.client(t, Config{})
+
+	for _, filter := range []map[string]interface{}{nil, {}} {
+		if _, err := client.Items.UpdateByQuery(context.Background(), "articles", filter, Item{"status": "draft"}); !errors.Is(err, ErrEmptyFilter) {
+			t.Errorf("UpdateByQuery(%v) error = %v, want ErrEmptyFilter", filter, err)
+		}
+	}
+	if requests := server.recorded(); len(requests) != 0 {
+		t.Fatalf("sent %d requests, want none", len(requests))
+	}
+
+	filter := NewFilterEqual("status", "review")
+	if _, err := client.Items.UpdateByQuery(context.Background(), "articles", filter, Item{"status": "draft"}); err != nil {
+		t.Fatalf("UpdateByQuery: %v", err)
+	}
+	want := `{"data":{"status":"draft"},"query":{"filter":{"status":{"_eq":"review"}},"limit":-1}}`
+	if requests := server.recorded(); len(requests) != 1 || requests[0].Body != want {
+		t.Errorf("UpdateByQuery sent %+v, want body %s", requests, want)
+	}
+}
@@ -1,7 +1,13 @@
 package directus
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -14,13 +20,64 @@ type Config struct {
 	Email    string
 	Password string
 	Timeout  time.Duration
+
+	// TokenSource supplies the access token for every request. It takes
+	// precedence over Token and Email/Password.
+	TokenSource TokenSource
+
+	// RefreshLeeway is how long before expiry the access token is refreshed.
+	// Defaults to 30 seconds.
+	RefreshLeeway time.Duration
+	// OnRefreshError is called whenever refreshing the access token fails
+	OnRefreshError func(err error)
+
+	// Retry configures retries of failed requests. Nil disables retries.
+	Retry *RetryPolicy
+
+	// RateLimit limits the request rate and concurrency of the whole client
+	RateLimit *RateLimit
+	// ServiceLimits adds budgets per service, keyed by Operation.Service
+	// such as "items", "files" or "users"
+	ServiceLimits map[string]RateLimit
+	// CollectionLimits adds budgets per collection for item requests
+	CollectionLimits map[string]RateLimit
+
+	// HTTPClient is used as the underlying HTTP client. It is copied, not
+	// modified, and its transport is wrapped by the SDK.
+	HTTPClient *http.Client
+	// Transport is the base round tripper, overriding HTTPClient's transport
+	Transport http.RoundTripper
+	// TLSConfig, RootCAs and Certificates configure TLS, e.g. an internal CA
+	// and client certificates for mTLS
+	TLSConfig    *tls.Config
+	RootCAs      *x509.CertPool
+	Certificates []tls.Certificate
+	// ProxyURL routes requests through a proxy, e.g. "http://proxy:3128"
+	ProxyURL string
+	// Pool configures connection pooling
+	Pool PoolConfig
+	// Headers are sent with every request
+	Headers map[string]string
+	// UserAgent replaces the default User-Agent header
+	UserAgent string
+
+	// Logger receives a record for every request. Nil disables logging.
+	Logger *slog.Logger
+	// Log configures levels and body logging for Logger
+	Log LogConfig
 }
 
 // Client represents a Directus API client
 type Client struct {
-	httpClient  *resty.Client
-	baseURL     string
-	token       string
+	httpClient *resty.Client
+	baseURL    string
+	session    *session
+	tokenMu    sync.RWMutex
+	tokens     TokenSource
+
+	middlewareMu sync.RWMutex
+	middleware   []Middleware
+
 	Collections *CollectionsService
 	Items       *ItemsService
 	Files       *FilesService
@@ -28,6 +85,7 @@ type Client struct {
 	Roles       *RolesService
 	Services    *ServicesService
 	System      *SystemService
+	Server      *ServerService
 	Settings    *SettingsService
 	Flow        *FlowService
 	Relations   *RelationsService
@@ -39,27 +97,61 @@ func NewClient(config Config) (*Client, error) {
 		return nil, fmt.Errorf("base URL is required")
 	}
 
+	leeway := config.RefreshLeeway
+	if leeway <= 0 {
+		leeway = defaultRefreshLeeway
+	}
+
 	client := &Client{
 		baseURL: config.BaseURL,
-		token:   config.Token,
 	}
 
 	// Initialize HTTP client
-	httpClient := resty.New().
+	hc, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := resty.NewWithClient(hc).
 		SetBaseURL(config.BaseURL).
-		SetTimeout(config.Timeout)
+		SetHeaders(config.Headers)
+	if config.UserAgent != "" {
+		httpClient.SetHeader("User-Agent", config.UserAgent)
+	}
+
+	client.session = &session{
+		httpClient:     httpClient,
+		accessToken:    config.Token,
+		email:          config.Email,
+		password:       config.Password,
+		leeway:         leeway,
+		onRefreshError: config.OnRefreshError,
+	}
+	// Budgets apply below authentication, so a retried request counts once
+	// per attempt. authTransport releases a rejected response, and its slot,
+	// before refreshing the token.
+	transport := newLimitTransport(config.RateLimit, config.ServiceLimits, config.CollectionLimits, httpClient.GetClient().Transport)
+	transport = &authTransport{client: client, base: transport}
+	if config.Retry != nil {
+		transport = &retryTransport{policy: config.Retry, base: transport}
+	}
+	if config.Logger != nil {
+		transport = newLoggingTransport(config.Logger, config.Log, transport)
+	}
+	transport = &middlewareTransport{client: client, base: transport}
+	httpClient.SetTransport(transport)
 
 	// Set authentication
-	if config.Token != "" {
-		httpClient.SetAuthToken(config.Token)
-	} else if config.Email != "" && config.Password != "" {
+	client.tokens = client.session
+	if config.TokenSource != nil {
+		client.tokens = config.TokenSource
+	} else if config.Token == "" && config.Email != "" && config.Password != "" {
 		// Authenticate with email/password
-		token, err := authenticate(httpClient, config.Email, config.Password)
+		data, err := authenticate(context.Background(), httpClient, config.Email, config.Password)
 		if err != nil {
 			return nil, fmt.Errorf("authentication failed: %w", err)
 		}
-		client.token = token
-		httpClient.SetAuthToken(token)
+		client.session.set(data)
 	}
 
 	client.httpClient = httpClient
@@ -72,6 +164,7 @@ func NewClient(config Config) (*Client, error) {
 	client.Roles = NewRolesService(client)
 	client.Services = NewServicesService(client)
 	client.System = NewSystemService(client)
+	client.Server = NewServerService(client)
 	client.Settings = NewSettingsService(client)
 	client.Flow = NewFlowService(client)
 	client.Relations = NewRelationsService(client)
@@ -86,32 +179,33 @@ func (c *Client) GetBaseURL() string {
 
 // GetToken returns the current token
 func (c *Client) GetToken() string {
-	return c.token
-}
-
-// authenticate performs authentication with email/password
-func authenticate(client *resty.Client, email, password string) (string, error) {
-	var resp struct {
-		Data struct {
-			AccessToken string `json:"access_token"`
-		} `json:"data"`
+	src := c.tokenSource()
+	if src == nil {
+		return ""
 	}
-
-	response, err := client.R().
-		SetBody(map[string]string{
-			"email":    email,
-			"password": password,
-		}).
-		SetResult(&resp).
-		Post("/auth/login")
-
-	if err != nil {
-		return "", err
+	token, err := src.Token(context.Background())
+	if err != nil || token == nil {
+		return ""
 	}
+	return token.AccessToken
+}
 
-	if response.StatusCode() != 200 {
-		return "", fmt.Errorf("authentication failed: %s", response.Status())
-	}
+// SetToken replaces the token source with a static access token
+func (c *Client) SetToken(token string) {
+	c.SetTokenSource(StaticTokenSource(token))
+}
+
+// SetTokenSource replaces the source the client asks for access tokens.
+// It is safe to call while requests are in flight.
+func (c *Client) SetTokenSource(src TokenSource) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.tokens = src
+}
 
-	return resp.Data.AccessToken, nil
+// tokenSource returns the source the client currently asks for access tokens
+func (c *Client) tokenSource() TokenSource {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.tokens
 }
@@ -0,0 +1,71 @@
+package directus
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// testRequest is a request received by a testServer
+type testRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   string
+}
+
+// testServer is a Directus stand-in that records the requests it receives
+// and answers them with respond
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []testRequest
+}
+
+func newTestServer(t *testing.T, respond func(w http.ResponseWriter, r *testRequest)) *testServer {
+	t.Helper()
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request := testRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header.Clone(), Body: string(body)}
+		s.mu.Lock()
+		s.requests = append(s.requests, request)
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		respond(w, &request)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// client creates a client of the server with a static token
+func (s *testServer) client(t *testing.T, config Config) *Client {
+	t.Helper()
+	config.BaseURL = s.URL
+	config.Token = "token"
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+// recorded returns the requests received so far
+func (s *testServer) recorded() []testRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]testRequest(nil), s.requests...)
+}
+
+// writeJSON writes v as the JSON response body with status
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
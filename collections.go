@@ -16,46 +16,46 @@ func NewCollectionsService(client *Client) *CollectionsService {
 }
 
 // Get retrieves a collection by name
-func (s *CollectionsService) Get(ctx context.Context, name string) (*Collection, error) {
+func (s *CollectionsService) Get(ctx context.Context, name string, params ...*QueryParams) (*Collection, error) {
 	var resp struct {
 		Data Collection `json:"data"`
 	}
 	path := fmt.Sprintf("/collections/%s", name)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "collections", Name: "get", Collection: name}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get collection: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
 // List retrieves all collections
-func (s *CollectionsService) List(ctx context.Context) ([]Collection, error) {
+func (s *CollectionsService) List(ctx context.Context, params ...*QueryParams) ([]Collection, error) {
 	var resp struct {
 		Data []Collection `json:"data"`
 	}
 	path := "/collections"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "collections", Name: "list"}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to list collections: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return resp.Data, nil
@@ -68,8 +68,7 @@ func (s *CollectionsService) Create(ctx context.Context, collection *Collection)
 	}
 	path := "/collections"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "collections", Name: "create"}).
 		SetBody(collection).
 		SetResult(&resp).
 		Post(path)
@@ -78,32 +77,50 @@ func (s *CollectionsService) Create(ctx context.Context, collection *Collection)
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to create collection: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
-// Update updates an existing collection
+// Update updates an existing collection. Fields left at their zero value are not
+// sent; use Patch to clear them.
 func (s *CollectionsService) Update(ctx context.Context, name string, collection *Collection) (*Collection, error) {
+	body, err := Diff(nil, collection)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, name, body)
+}
+
+// Patch updates the fields of a collection that differ between original and
+// modified, see Diff
+func (s *CollectionsService) Patch(ctx context.Context, name string, original, modified *Collection) (*Collection, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, name, patch)
+}
+
+// update patches a collection with body
+func (s *CollectionsService) update(ctx context.Context, name string, body Item) (*Collection, error) {
 	var resp struct {
 		Data Collection `json:"data"`
 	}
-	path := fmt.Sprintf("/collections/%s", name)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(collection).
+	response, err := s.client.request(ctx, Operation{Service: "collections", Name: "update", Collection: name}).
+		SetBody(body).
 		SetResult(&resp).
-		Patch(path)
+		Patch(fmt.Sprintf("/collections/%s", name))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update collection: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -113,16 +130,15 @@ func (s *CollectionsService) Update(ctx context.Context, name string, collection
 func (s *CollectionsService) Delete(ctx context.Context, name string) error {
 	path := fmt.Sprintf("/collections/%s", name)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "collections", Name: "delete", Collection: name}).
 		Delete(path)
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != 204 {
-		return fmt.Errorf("failed to delete collection: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
 	}
 
 	return nil
@@ -0,0 +1,177 @@
+package directus
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DeepQuery sets query parameters on the related items of relational
+// fields, such as the filter, sort and limit of the comments of each
+// article. Relations are given by path, so nested relations need no
+// hand-written "_" prefixes. Errors are kept until Build.
+//
+//	deep := directus.NewDeepQuery().
+//		Relation("comments", &directus.QueryParams{Sort: []string{"-date_created"}, Limit: 5}).
+//		Relation("comments.replies", &directus.QueryParams{Limit: 1})
+type DeepQuery struct {
+	relations map[string]*QueryParams
+	err       error
+}
+
+// NewDeepQuery creates an empty deep query
+func NewDeepQuery() *DeepQuery {
+	return &DeepQuery{relations: make(map[string]*QueryParams)}
+}
+
+// Relation sets the query parameters of a relational field. Nested
+// relations are separated by dots, like "comments.author". The filter,
+// search, sort, limit, offset and page of params apply to the related
+// items; their fields are selected in the Fields of the top-level query.
+func (d *DeepQuery) Relation(path string, params *QueryParams) *DeepQuery {
+	if d.err != nil {
+		return d
+	}
+	for _, part := range strings.Split(path, ".") {
+		if part == "" || part == "*" || strings.HasPrefix(part, "_") {
+			d.err = fmt.Errorf("deep query on %q: invalid relation path", path)
+			return d
+		}
+	}
+	if _, err := deepParams(params); err != nil {
+		d.err = fmt.Errorf("deep query on %q: %w", path, err)
+		return d
+	}
+	d.relations[path] = params
+	return d
+}
+
+// Paths returns the relation paths of the deep query, sorted
+func (d *DeepQuery) Paths() []string {
+	paths := make([]string, 0, len(d.relations))
+	for path := range d.relations {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// Err returns the first error of the deep query, if any
+func (d *DeepQuery) Err() error {
+	return d.err
+}
+
+// Build compiles the deep query to the form Directus expects
+func (d *DeepQuery) Build() (map[string]interface{}, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	compiled := make(map[string]interface{})
+	for _, path := range d.Paths() {
+		node := compiled
+		for _, part := range strings.Split(path, ".") {
+			child, ok := node[part].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[part] = child
+			}
+			node = child
+		}
+		params, _ := deepParams(d.relations[path])
+		for key, value := range params {
+			node[key] = value
+		}
+	}
+	return compiled, nil
+}
+
+// SetDeep builds deep into the query parameters. Every relation of deep must
+// have fields of its related items selected in Fields, like "comments.*" for
+// "comments" or "comments.replies.*" for "comments.replies", or Directus
+// ignores it.
+func (qp *QueryParams) SetDeep(deep *DeepQuery) error {
+	compiled, err := deep.Build()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range deep.Paths() {
+		if !selectsRelation(qp.Fields, path) {
+			return fmt.Errorf("deep query on %q: relation is not selected in Fields", path)
+		}
+	}
+
+	qp.Deep = compiled
+	return nil
+}
+
+// deepParams returns the parameters Directus accepts on related items, with
+// their "_" prefix
+func deepParams(params *QueryParams) (map[string]interface{}, error) {
+	compiled := make(map[string]interface{})
+	if params == nil {
+		return compiled, nil
+	}
+
+	var unsupported []string
+	if len(params.Fields) > 0 || len(params.Aliases) > 0 {
+		unsupported = append(unsupported, "Fields")
+	}
+	if params.Deep != nil {
+		unsupported = append(unsupported, "Deep")
+	}
+	if len(params.Aggregate) > 0 || len(params.GroupBy) > 0 {
+		unsupported = append(unsupported, "Aggregate")
+	}
+	if params.Export != "" || params.Lang != "" || len(params.Meta) > 0 || params.Version != "" || params.Backlink != nil {
+		unsupported = append(unsupported, "top-level parameters")
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("%s not supported on related items", strings.Join(unsupported, ", "))
+	}
+
+	if params.Filter != nil {
+		compiled["_filter"] = params.Filter
+	}
+	if params.Search != "" {
+		compiled["_search"] = params.Search
+	}
+	if len(params.Sort) > 0 {
+		compiled["_sort"] = params.Sort
+	}
+	if params.Limit != 0 {
+		compiled["_limit"] = params.Limit
+	}
+	if params.Offset > 0 {
+		compiled["_offset"] = params.Offset
+	}
+	if params.Page > 0 {
+		compiled["_page"] = params.Page
+	}
+	return compiled, nil
+}
+
+// selectsRelation reports whether fields select fields of the related items
+// at path, by name or with wildcards like "*.*". A field must be longer than
+// the path: "*" or "comments" alone return keys, not related items.
+func selectsRelation(fields []string, path string) bool {
+	parts := strings.Split(path, ".")
+	for _, field := range fields {
+		segments := strings.Split(strings.TrimSpace(field), ".")
+		if len(segments) <= len(parts) {
+			continue
+		}
+		selected := true
+		for i, part := range parts {
+			if segments[i] != part && segments[i] != "*" {
+				selected = false
+				break
+			}
+		}
+		if selected {
+			return true
+		}
+	}
+	return false
+}
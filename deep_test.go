@@ -0,0 +1,97 @@
+package directus
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeepQueryBuild(t *testing.T) {
+	comments := &QueryParams{Sort: []string{"-date_created"}, Limit: 5, Search: "go", Offset: 1, Page: 2}
+	if err := comments.SetFilter(F("status").Eq("approved")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NewDeepQuery().
+		Relation("comments.replies", &QueryParams{Limit: 1}).
+		Relation("comments", comments).
+		Relation("translations", nil).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"comments": map[string]interface{}{
+			"_filter": map[string]interface{}{"status": map[string]interface{}{"_eq": "approved"}},
+			"_sort":   []string{"-date_created"},
+			"_limit":  5,
+			"_offset": 1,
+			"_page":   2,
+			"_search": "go",
+			"replies": map[string]interface{}{"_limit": 1},
+		},
+		"translations": map[string]interface{}{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build = %v, want %v", got, want)
+	}
+}
+
+func TestDeepQueryErrors(t *testing.T) {
+	tests := []struct {
+		deep *DeepQuery
+		want string
+	}{
+		{NewDeepQuery().Relation("", nil), "invalid relation path"},
+		{NewDeepQuery().Relation("comments._filter", nil), "invalid relation path"},
+		{NewDeepQuery().Relation("*", nil), "invalid relation path"},
+		{NewDeepQuery().Relation("comments", &QueryParams{Fields: []string{"id"}}), "Fields not supported"},
+		{NewDeepQuery().Relation("comments", &QueryParams{Deep: map[string]interface{}{}}), "Deep not supported"},
+		{NewDeepQuery().Relation("comments", &QueryParams{GroupBy: []string{"status"}}), "Aggregate not supported"},
+		{NewDeepQuery().Relation("comments", &QueryParams{Lang: "en"}), "top-level parameters not supported"},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.deep.Build()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Build error = %v, want one containing %q", err, tt.want)
+		}
+	}
+}
+
+func TestSetDeepValidatesFields(t *testing.T) {
+	tests := []struct {
+		fields []string
+		path   string
+		ok     bool
+	}{
+		{[]string{"id", "comments.*"}, "comments", true},
+		{[]string{"comments.body"}, "comments", true},
+		{[]string{"*.*"}, "comments", true},
+		{[]string{"comments.replies.*"}, "comments.replies", true},
+		{[]string{"comments.*.*"}, "comments.replies", true},
+		{[]string{"*.*.*"}, "comments.replies", true},
+		{nil, "comments", false},
+		{[]string{"*"}, "comments", false},
+		{[]string{"comments"}, "comments", false},
+		{[]string{"author.*"}, "comments", false},
+		{[]string{"comments.*"}, "comments.replies", false},
+		{[]string{"comments.replies"}, "comments.replies", false},
+		{[]string{"*.*"}, "comments.replies", false},
+	}
+
+	for _, tt := range tests {
+		qp := &QueryParams{Fields: tt.fields}
+		err := qp.SetDeep(NewDeepQuery().Relation(tt.path, &QueryParams{Limit: 1}))
+		if (err == nil) != tt.ok {
+			t.Errorf("SetDeep(%q) with Fields %q: error = %v, want ok %v", tt.path, tt.fields, err, tt.ok)
+		}
+		if err == nil && qp.Deep == nil {
+			t.Errorf("SetDeep(%q) with Fields %q did not set Deep", tt.path, tt.fields)
+		}
+		if err != nil && qp.Deep != nil {
+			t.Errorf("SetDeep(%q) with Fields %q set Deep despite an error", tt.path, tt.fields)
+		}
+	}
+}
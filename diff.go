@@ -0,0 +1,234 @@
+package directus
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Diff returns the minimal patch that turns original into modified: the
+// fields whose JSON value changed, compared through their json tags. original
+// and modified are both Items or maps, or both structs or pointers to structs.
+// A nil original stands for an empty Item, or the zero value of the struct, so
+// Diff(nil, modified) returns the fields modified sets.
+//
+// A nil value in a modified Item is an explicit null. A field missing from a
+// modified Item is left unchanged, while a field a modified struct omits
+// through omitempty, like a nil pointer, is sent as null when original has
+// it. Nested structs are compared the same way, so only their changed fields
+// are sent, and changed lists of structs are sent with only the fields each
+// element sets. Maps, Items, JSON fields and Refs are sent whole when they
+// changed.
+func Diff(original, modified interface{}) (Item, error) {
+	after, structured, err := jsonObject(modified)
+	if err != nil {
+		return nil, fmt.Errorf("diff: modified: %w", err)
+	}
+	if after == nil {
+		return nil, fmt.Errorf("diff: modified is nil")
+	}
+
+	var t reflect.Type
+	if structured {
+		t = reflect.Indirect(reflect.ValueOf(modified)).Type()
+		if isNil(original) {
+			original = reflect.New(t).Interface()
+		} else if ot := reflect.Indirect(reflect.ValueOf(original)).Type(); ot != t {
+			return nil, fmt.Errorf("diff: original is %v, modified is %v", ot, t)
+		}
+	}
+	before, _, err := jsonObject(original)
+	if err != nil {
+		return nil, fmt.Errorf("diff: original: %w", err)
+	}
+
+	return diffObjects(before, after, t)
+}
+
+// diffObjects returns the patch between two JSON objects. t is the struct
+// they were encoded from, or nil for maps.
+func diffObjects(before, after map[string]interface{}, t reflect.Type) (Item, error) {
+	var fields map[string]reflect.Type
+	if t != nil {
+		fields = make(map[string]reflect.Type)
+		jsonFields(t, fields)
+	}
+
+	patch := Item{}
+	for field, value := range after {
+		previous, ok := before[field]
+		if ok && reflect.DeepEqual(previous, value) {
+			continue
+		}
+
+		nested, list := nestedStruct(fields[field])
+		switch {
+		case nested == nil || value == nil:
+			patch[field] = value
+		case list:
+			elements, err := sparseList(value, nested)
+			if err != nil {
+				return nil, fmt.Errorf("diff: field %s: %w", field, err)
+			}
+			patch[field] = elements
+		default:
+			// A missing or null nested struct stands for its zero value
+			previousObject, _ := previous.(map[string]interface{})
+			if previousObject == nil {
+				var err error
+				if previousObject, err = zeroObject(nested); err != nil {
+					return nil, fmt.Errorf("diff: field %s: %w", field, err)
+				}
+			}
+			object, _ := value.(map[string]interface{})
+			changes, err := diffObjects(previousObject, object, nested)
+			if err != nil {
+				return nil, fmt.Errorf("diff: field %s: %w", field, err)
+			}
+			if len(changes) > 0 {
+				patch[field] = changes
+			}
+		}
+	}
+	if t != nil {
+		for field, previous := range before {
+			if _, ok := after[field]; !ok && previous != nil {
+				patch[field] = nil
+			}
+		}
+	}
+	return patch, nil
+}
+
+// sparseList returns a JSON list of structs of type t with only the fields
+// each element sets
+func sparseList(value interface{}, t reflect.Type) ([]interface{}, error) {
+	list, _ := value.([]interface{})
+	zero, err := zeroObject(t)
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]interface{}, len(list))
+	for n, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			elements[n] = element
+			continue
+		}
+		if elements[n], err = diffObjects(zero, object, t); err != nil {
+			return nil, err
+		}
+	}
+	return elements, nil
+}
+
+// zeroObject returns the JSON object of the zero value of the struct t
+func zeroObject(t reflect.Type) (map[string]interface{}, error) {
+	object, _, err := jsonObject(reflect.New(t).Interface())
+	return object, err
+}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// nestedStruct returns the struct of a field of type t that encodes as a
+// JSON object, or as a list of them when list is set. Structs with their own
+// JSON encoding, like time.Time and Ref, are values, not nested structs.
+func nestedStruct(t reflect.Type) (nested reflect.Type, list bool) {
+	for t != nil {
+		if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+			return nil, false
+		}
+		switch t.Kind() {
+		case reflect.Pointer:
+			t = t.Elem()
+		case reflect.Slice, reflect.Array:
+			if list || t.Elem().Kind() == reflect.Uint8 {
+				return nil, false
+			}
+			t, list = t.Elem(), true
+		case reflect.Struct:
+			return t, list
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// jsonFields adds the types of the fields of the struct t by JSON name,
+// flattening embedded structs like encoding/json does
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		embeddedStruct := field.Anonymous && embedded.Kind() == reflect.Struct
+		if embeddedStruct && name == "" {
+			jsonFields(embedded, fields)
+			continue
+		}
+		if !field.IsExported() && !embeddedStruct {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = field.Type
+		}
+	}
+}
+
+// jsonObject returns the JSON object v encodes to, and whether v is a struct.
+// A nil v has no object.
+func jsonObject(v interface{}) (map[string]interface{}, bool, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, false, nil
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil, false, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, false, nil
+		}
+	case reflect.Struct:
+	default:
+		return nil, false, fmt.Errorf("%T is not a map or a struct", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, false, err
+	}
+	var object map[string]interface{}
+	if err := unmarshalNumber(data, &object); err != nil {
+		return nil, false, err
+	}
+	return object, rv.Kind() == reflect.Struct, nil
+}
+
+// isNil reports whether v is nil or a nil pointer or map
+func isNil(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
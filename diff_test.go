@@ -0,0 +1,217 @@
+package directus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// article is a typed item with a relation, a JSON field and an embedded struct
+type article struct {
+	auditFields
+	ID       int                    `json:"id,omitempty"`
+	Title    string                 `json:"title"`
+	Subtitle *string                `json:"subtitle,omitempty"`
+	Author   Ref[User]              `json:"author,omitempty"`
+	Metadata json.RawMessage        `json:"metadata,omitempty"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+}
+
+type auditFields struct {
+	DateCreated time.Time `json:"date_created"`
+	Status      string    `json:"status,omitempty"`
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name               string
+		original, modified interface{}
+		want               string
+	}{
+		// Items
+		{"item changed field", Item{"a": 1, "b": 2}, Item{"a": 1, "b": 3}, `{"b":3}`},
+		{"item explicit null", Item{"a": 1, "b": 2}, Item{"a": 1, "b": nil}, `{"b":null}`},
+		{"item missing field unchanged", Item{"a": 1, "b": 2}, Item{"a": 1}, `{}`},
+		{"item new field", Item{"a": 1}, Item{"a": 1, "b": "x"}, `{"b":"x"}`},
+		{"item nil original", nil, Item{"a": 1, "b": nil}, `{"a":1,"b":null}`},
+		{"item nested object whole", Item{"m": map[string]interface{}{"x": 1, "y": 2}}, Item{"m": map[string]interface{}{"x": 1, "y": 3}}, `{"m":{"x":1,"y":3}}`},
+		{"item numbers", Item{"n": 1}, Item{"n": float64(1)}, `{}`},
+
+		// Structs
+		{"struct nil original", nil, &User{Email: "ann@example.com"}, `{"email":"ann@example.com"}`},
+		{"struct typed nil original", (*User)(nil), User{Status: "active"}, `{"status":"active"}`},
+		{"struct unchanged", &User{Email: "a", Status: "active"}, &User{Email: "a", Status: "active"}, `{}`},
+		{"struct set to zero", &User{Email: "a", Status: "active"}, &User{Email: "a"}, `{"status":""}`},
+		{"struct set to false", &Role{Name: "r", AdminAccess: true}, &Role{Name: "r"}, `{"admin_access":false}`},
+		{"struct omitempty cleared", &User{Email: "a", Role: ptr("r1")}, &User{Email: "a"}, `{"role":null}`},
+		{"struct omitempty unset", &User{Email: "a"}, &User{Email: "a", Role: ptr("r1")}, `{"role":"r1"}`},
+		{"struct named embedded", &struct {
+			auditFields `json:"audit"`
+		}{auditFields{Status: "draft"}}, &struct {
+			auditFields `json:"audit"`
+		}{auditFields{DateCreated: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Status: "draft"}}, `{"audit":{"date_created":"2024-01-02T00:00:00Z"}}`},
+		{"struct embedded", &article{Title: "t"}, &article{auditFields: auditFields{DateCreated: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, Title: "t"}, `{"date_created":"2024-01-02T00:00:00Z"}`},
+		{"struct ref whole", &article{Author: RefItem(&User{ID: "u1"})}, &article{Author: RefItem(&User{ID: "u2"})}, `{"author":{"email":"","id":"u2","last_access":"0001-01-01T00:00:00Z","provider":"","status":"","tfa_secret":false}}`},
+		{"struct ref id", nil, &article{Author: RefID[User]("u1")}, `{"author":"u1"}`},
+		{"struct json field whole", &article{Metadata: json.RawMessage(`{"a":1,"b":2}`)}, &article{Metadata: json.RawMessage(`{"a":1,"b":3}`)}, `{"metadata":{"a":1,"b":3}}`},
+		{"struct map whole", &article{Options: map[string]interface{}{"a": 1, "b": 2}}, &article{Options: map[string]interface{}{"a": 1, "b": 3}}, `{"options":{"a":1,"b":3}}`},
+
+		// Nested structs
+		{
+			"nested nil original",
+			nil,
+			&Collection{Meta: &CollectionMeta{Note: ptr("x")}},
+			`{"meta":{"note":"x"}}`,
+		},
+		{
+			"nested changed leaves",
+			&Collection{Collection: "articles", Meta: &CollectionMeta{Collection: "articles", Hidden: true, Note: ptr("a"), Icon: ptr("box")}},
+			&Collection{Collection: "articles", Meta: &CollectionMeta{Collection: "articles", Hidden: false, Icon: ptr("box")}},
+			`{"meta":{"hidden":false,"note":null}}`,
+		},
+		{
+			"nested unchanged",
+			&Collection{Collection: "articles", Meta: &CollectionMeta{Hidden: true}},
+			&Collection{Collection: "articles", Meta: &CollectionMeta{Hidden: true}},
+			`{}`,
+		},
+		{
+			"nested removed",
+			&Collection{Meta: &CollectionMeta{Hidden: true}},
+			&Collection{},
+			`{"meta":null}`,
+		},
+		{
+			"nested added to original",
+			&Flow{Name: "f"},
+			&Flow{Name: "f", Operation: &FlowOperation{Key: "log", PositionX: 1}},
+			`{"operation":{"key":"log","position_x":1}}`,
+		},
+		{
+			"nested map whole",
+			&Flow{Operation: &FlowOperation{Key: "log", Options: map[string]interface{}{"a": 1, "b": 2}}},
+			&Flow{Operation: &FlowOperation{Key: "log", Options: map[string]interface{}{"a": 1, "b": 3}}},
+			`{"operation":{"options":{"a":1,"b":3}}}`,
+		},
+		{
+			"list of structs",
+			nil,
+			&Role{Users: []User{{ID: "u1"}, {ID: "u2", Status: "active"}}},
+			`{"users":[{"id":"u1"},{"id":"u2","status":"active"}]}`,
+		},
+		{
+			"list of structs unchanged",
+			&Role{Name: "r", Users: []User{{ID: "u1"}}},
+			&Role{Name: "r", Users: []User{{ID: "u1"}}},
+			`{}`,
+		},
+		{
+			"list of structs cleared",
+			&Role{Users: []User{{ID: "u1"}}},
+			&Role{},
+			`{"users":null}`,
+		},
+		{"list of strings whole", &User{Email: "a", Tags: []string{"x"}}, &User{Email: "a", Tags: []string{"x", "y"}}, `{"tags":["x","y"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := Diff(tt.original, tt.modified)
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			got, err := json.Marshal(patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Diff = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	tests := []struct {
+		name               string
+		original, modified interface{}
+		want               string
+	}{
+		{"nil modified", Item{}, nil, "modified is nil"},
+		{"nil struct modified", nil, (*User)(nil), "modified is nil"},
+		{"not an object", nil, []string{"a"}, "not a map or a struct"},
+		{"struct and item", Item{"email": "a"}, &User{}, "original is directus.Item, modified is directus.User"},
+		{"different structs", &Role{}, &User{}, "original is directus.Role, modified is directus.User"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Diff(tt.original, tt.modified)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Diff error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateSendsChangedFields(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{}})
+	})
+	client := server.client(t, Config{})
+	ctx := context.Background()
+
+	calls := []func() error{
+		func() error {
+			_, err := client.Collections.Update(ctx, "articles", &Collection{Meta: &CollectionMeta{Note: ptr("x")}})
+			return err
+		},
+		func() error {
+			_, err := client.Roles.Update(ctx, "r1", &Role{Users: []User{{ID: "u1"}}})
+			return err
+		},
+		func() error {
+			_, err := client.Flow.Update(ctx, "f1", &Flow{Operation: &FlowOperation{Key: "log"}})
+			return err
+		},
+		func() error {
+			original := &Collection{Collection: "articles", Meta: &CollectionMeta{Collection: "articles", Hidden: true}}
+			modified := &Collection{Collection: "articles", Meta: &CollectionMeta{Collection: "articles"}}
+			_, err := client.Collections.Patch(ctx, "articles", original, modified)
+			return err
+		},
+		func() error {
+			_, err := client.System.PatchSettings(ctx, &SystemSettings{AuthLoginAttempts: 25}, &SystemSettings{})
+			return err
+		},
+	}
+	want := []string{
+		`PATCH /collections/articles {"meta":{"note":"x"}}`,
+		`PATCH /roles/r1 {"users":[{"id":"u1"}]}`,
+		`PATCH /flows/f1 {"operation":{"key":"log"}}`,
+		`PATCH /collections/articles {"meta":{"hidden":false}}`,
+		`PATCH /system/settings {"auth_login_attempts":0}`,
+	}
+
+	for i, call := range calls {
+		if err := call(); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	requests := server.recorded()
+	if len(requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(requests), len(want))
+	}
+	for i, r := range requests {
+		if got := r.Method + " " + r.Path + " " + r.Body; got != want[i] {
+			t.Errorf("request %d = %s, want %s", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,24 @@
+module github.com/rhyoharianja/go-directusSDK/directusotel
+
+go 1.24.3
+
+require (
+	github.com/rhyoharianja/go-directusSDK v0.0.0
+	go.opentelemetry.io/otel v1.37.0
+	go.opentelemetry.io/otel/metric v1.37.0
+	go.opentelemetry.io/otel/sdk v1.37.0
+	go.opentelemetry.io/otel/sdk/metric v1.37.0
+	go.opentelemetry.io/otel/trace v1.37.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/go-resty/resty/v2 v2.16.5 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+)
+
+replace github.com/rhyoharianja/go-directusSDK => ../
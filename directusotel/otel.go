@@ -0,0 +1,172 @@
+// Package directusotel instruments a Directus client with OpenTelemetry
+// tracing and metrics.
+//
+//	client.Use(directusotel.Middleware())
+package directusotel
+
+import (
+	"net/http"
+	"time"
+
+	directus "github.com/rhyoharianja/go-directusSDK"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer and meter of this package
+const instrumentationName = "github.com/rhyoharianja/go-directusSDK/directusotel"
+
+// config holds the providers used by the middleware
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the middleware
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. Defaults to the global provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider. Defaults to the global provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagators sets the propagators used to inject trace context into
+// request headers. Defaults to the global propagators.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// instruments records request metrics
+type instruments struct {
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// newInstruments creates the metric instruments of meter
+func newInstruments(meter metric.Meter) *instruments {
+	var (
+		i   instruments
+		err error
+	)
+	i.requests, err = meter.Int64Counter("directus.client.requests",
+		metric.WithDescription("Number of Directus API calls"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	i.errors, err = meter.Int64Counter("directus.client.errors",
+		metric.WithDescription("Number of failed Directus API calls"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	i.duration, err = meter.Float64Histogram("directus.client.duration",
+		metric.WithDescription("Duration of Directus API calls, including retries"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &i
+}
+
+// Middleware returns a directus.Middleware that creates a client span per SDK
+// operation, named like "directus.items.list", propagates trace context to
+// the server and records request count, latency and errors per service and
+// collection.
+func Middleware(opts ...Option) directus.Middleware {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+	inst := newInstruments(c.meterProvider.Meter(instrumentationName))
+
+	return func(next directus.Handler) directus.Handler {
+		return func(req *directus.Request) (*http.Response, error) {
+			attrs := []attribute.KeyValue{
+				attribute.String("directus.service", req.Service),
+				attribute.String("directus.operation", req.Name),
+			}
+			if req.Collection != "" {
+				attrs = append(attrs, attribute.String("collection", req.Collection))
+			}
+
+			spanAttrs := append(attrs[:len(attrs):len(attrs)],
+				attribute.String("http.request.method", req.HTTP.Method),
+				attribute.String("url.path", req.HTTP.URL.Path),
+				attribute.String("server.address", req.HTTP.URL.Hostname()),
+			)
+			if req.ID != "" {
+				spanAttrs = append(spanAttrs, attribute.String("item.id", req.ID))
+			}
+
+			ctx, span := tracer.Start(req.HTTP.Context(), spanName(req.Operation),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(spanAttrs...))
+			defer span.End()
+
+			req.HTTP = req.HTTP.WithContext(ctx)
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(req.HTTP.Header))
+
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start).Seconds()
+
+			failed := err != nil
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+				span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					failed = true
+					span.SetStatus(codes.Error, resp.Status)
+				}
+			}
+
+			set := metric.WithAttributes(attrs...)
+			inst.requests.Add(ctx, 1, set)
+			inst.duration.Record(ctx, elapsed, set)
+			if failed {
+				inst.errors.Add(ctx, 1, set)
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// spanName returns the span name of an operation, e.g. "directus.items.list"
+func spanName(op directus.Operation) string {
+	if op.Service == "" {
+		return "directus.request"
+	}
+	if op.Name == "" {
+		return "directus." + op.Service
+	}
+	return "directus." + op.Service + "." + op.Name
+}
@@ -0,0 +1,228 @@
+package directusotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	directus "github.com/rhyoharianja/go-directusSDK"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recorder holds the spans and metrics recorded by an instrumented client
+type recorder struct {
+	spans   *tracetest.SpanRecorder
+	metrics *sdkmetric.ManualReader
+
+	mu          sync.Mutex
+	traceparent []string // traceparent header of each request the server received
+}
+
+// newInstrumentedClient creates a client instrumented with in-memory
+// providers. The server answers 404 for the item "missing" and an empty
+// success otherwise.
+func newInstrumentedClient(t *testing.T) (*directus.Client, *recorder) {
+	t.Helper()
+	rec := &recorder{
+		spans:   tracetest.NewSpanRecorder(),
+		metrics: sdkmetric.NewManualReader(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		rec.traceparent = append(rec.traceparent, r.Header.Get("traceparent"))
+		rec.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/items/articles/missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"message":"Not found","extensions":{"code":"ROUTE_NOT_FOUND"}}]}`)
+		case "/items/articles":
+			fmt.Fprint(w, `{"data":[]}`)
+		default:
+			fmt.Fprint(w, `{"data":{"id":1}}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec.spans))
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(rec.metrics))
+	t.Cleanup(func() {
+		tracerProvider.Shutdown(context.Background())
+		meterProvider.Shutdown(context.Background())
+	})
+
+	client, err := directus.NewClient(directus.Config{BaseURL: server.URL, Token: "token"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.Use(Middleware(
+		WithTracerProvider(tracerProvider),
+		WithMeterProvider(meterProvider),
+		WithPropagators(propagation.TraceContext{}),
+	))
+	return client, rec
+}
+
+// counter returns the value of the counter name for the data point with the
+// given attributes
+func (r *recorder) counter(t *testing.T, name string, attrs ...attribute.KeyValue) int64 {
+	t.Helper()
+	var data metricdata.ResourceMetrics
+	if err := r.metrics.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	want := attribute.NewSet(attrs...)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("%s is %T, want an int64 sum", name, m.Data)
+			}
+			for _, point := range sum.DataPoints {
+				if point.Attributes.Equals(&want) {
+					return point.Value
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// attributes returns the string attributes of a span
+func attributes(kvs []attribute.KeyValue) map[string]string {
+	attrs := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	return attrs
+}
+
+func TestMiddlewareSpans(t *testing.T) {
+	client, rec := newInstrumentedClient(t)
+	ctx := context.Background()
+
+	if _, _, err := client.Items.List(ctx, "articles", nil); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if _, err := client.Items.Get(ctx, "articles", "42", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	spans := rec.spans.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+
+	tests := []struct {
+		name  string
+		attrs map[string]string
+	}{
+		{"directus.items.list", map[string]string{
+			"directus.service":          "items",
+			"directus.operation":        "list",
+			"collection":                "articles",
+			"http.request.method":       "GET",
+			"url.path":                  "/items/articles",
+			"http.response.status_code": "200",
+		}},
+		{"directus.items.get", map[string]string{
+			"directus.service":          "items",
+			"directus.operation":        "get",
+			"collection":                "articles",
+			"item.id":                   "42",
+			"url.path":                  "/items/articles/42",
+			"http.response.status_code": "200",
+		}},
+	}
+	for i, tt := range tests {
+		span := spans[i]
+		if span.Name() != tt.name {
+			t.Errorf("span %d name = %q, want %q", i, span.Name(), tt.name)
+		}
+		got := attributes(span.Attributes())
+		for key, want := range tt.attrs {
+			if got[key] != want {
+				t.Errorf("%s attribute %s = %q, want %q", tt.name, key, got[key], want)
+			}
+		}
+		if _, ok := got["item.id"]; ok && tt.attrs["item.id"] == "" {
+			t.Errorf("%s has item.id %q, want none", tt.name, got["item.id"])
+		}
+		if span.Status().Code == codes.Error {
+			t.Errorf("%s status = %v, want not an error", tt.name, span.Status())
+		}
+	}
+}
+
+func TestMiddlewarePropagatesTraceContext(t *testing.T) {
+	client, rec := newInstrumentedClient(t)
+
+	if _, err := client.Items.Get(context.Background(), "articles", "42", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	spans := rec.spans.Ended()
+	if len(spans) != 1 || len(rec.traceparent) != 1 {
+		t.Fatalf("spans, requests = %d, %d, want 1, 1", len(spans), len(rec.traceparent))
+	}
+	sc := spans[0].SpanContext()
+	want := fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+	if rec.traceparent[0] != want {
+		t.Errorf("traceparent = %q, want %q", rec.traceparent[0], want)
+	}
+}
+
+func TestMiddlewareCountsErrors(t *testing.T) {
+	client, rec := newInstrumentedClient(t)
+	ctx := context.Background()
+
+	if _, err := client.Items.Get(ctx, "articles", "42", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	ok := []attribute.KeyValue{
+		attribute.String("directus.service", "items"),
+		attribute.String("directus.operation", "get"),
+		attribute.String("collection", "articles"),
+		attribute.Int("http.response.status_code", http.StatusOK),
+	}
+	if n := rec.counter(t, "directus.client.errors", ok...); n != 0 {
+		t.Errorf("errors after success = %d, want 0", n)
+	}
+
+	for range 2 {
+		_, err := client.Items.Get(ctx, "articles", "missing", nil)
+		if !errors.Is(err, directus.ErrNotFound) {
+			t.Fatalf("Get error = %v, want ErrNotFound", err)
+		}
+	}
+	notFound := append(ok[:3:3], attribute.Int("http.response.status_code", http.StatusNotFound))
+	if n := rec.counter(t, "directus.client.errors", notFound...); n != 2 {
+		t.Errorf("errors after 404 = %d, want 2", n)
+	}
+	if n := rec.counter(t, "directus.client.requests", notFound...); n != 2 {
+		t.Errorf("requests after 404 = %d, want 2", n)
+	}
+	if n := rec.counter(t, "directus.client.requests", ok...); n != 1 {
+		t.Errorf("requests after success = %d, want 1", n)
+	}
+
+	spans := rec.spans.Ended()
+	if last := spans[len(spans)-1]; last.Status().Code != codes.Error {
+		t.Errorf("404 span status = %v, want an error", last.Status())
+	}
+}
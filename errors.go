@@ -0,0 +1,137 @@
+package directus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError through errors.Is
+var (
+	ErrBadRequest         = errors.New("directus: bad request")
+	ErrUnauthorized       = errors.New("directus: unauthorized")
+	ErrInvalidCredentials = errors.New("directus: invalid credentials")
+	ErrInvalidToken       = errors.New("directus: invalid token")
+	ErrTokenExpired       = errors.New("directus: token expired")
+	ErrInvalidOTP         = errors.New("directus: invalid otp")
+	ErrForbidden          = errors.New("directus: forbidden")
+	ErrNotFound           = errors.New("directus: not found")
+	ErrInvalidPayload     = errors.New("directus: invalid payload")
+	ErrInvalidQuery       = errors.New("directus: invalid query")
+	ErrRecordNotUnique    = errors.New("directus: record not unique")
+	ErrInvalidForeignKey  = errors.New("directus: invalid foreign key")
+	ErrNotNullViolation   = errors.New("directus: not null violation")
+	ErrValueTooLong       = errors.New("directus: value too long")
+	ErrValueOutOfRange    = errors.New("directus: value out of range")
+	ErrContentTooLarge    = errors.New("directus: content too large")
+	ErrUnprocessable      = errors.New("directus: unprocessable content")
+	ErrRateLimited        = errors.New("directus: requests exceeded")
+	ErrServiceUnavailable = errors.New("directus: service unavailable")
+	ErrServer             = errors.New("directus: server error")
+)
+
+// errorCodes maps Directus extensions.code values to sentinel errors
+var errorCodes = map[string]error{
+	"INVALID_CREDENTIALS":    ErrInvalidCredentials,
+	"INVALID_TOKEN":          ErrInvalidToken,
+	"TOKEN_EXPIRED":          ErrTokenExpired,
+	"INVALID_OTP":            ErrInvalidOTP,
+	"FORBIDDEN":              ErrForbidden,
+	"ROUTE_NOT_FOUND":        ErrNotFound,
+	"INVALID_PAYLOAD":        ErrInvalidPayload,
+	"INVALID_QUERY":          ErrInvalidQuery,
+	"RECORD_NOT_UNIQUE":      ErrRecordNotUnique,
+	"INVALID_FOREIGN_KEY":    ErrInvalidForeignKey,
+	"NOT_NULL_VIOLATION":     ErrNotNullViolation,
+	"VALUE_TOO_LONG":         ErrValueTooLong,
+	"VALUE_OUT_OF_RANGE":     ErrValueOutOfRange,
+	"CONTENT_TOO_LARGE":      ErrContentTooLarge,
+	"UNPROCESSABLE_CONTENT":  ErrUnprocessable,
+	"REQUESTS_EXCEEDED":      ErrRateLimited,
+	"SERVICE_UNAVAILABLE":    ErrServiceUnavailable,
+	"INTERNAL_SERVER_ERROR":  ErrServer,
+	"UNSUPPORTED_MEDIA_TYPE": ErrBadRequest,
+}
+
+// statusErrors maps HTTP status codes to sentinel errors
+var statusErrors = map[int]error{
+	http.StatusBadRequest:            ErrBadRequest,
+	http.StatusUnauthorized:          ErrUnauthorized,
+	http.StatusForbidden:             ErrForbidden,
+	http.StatusNotFound:              ErrNotFound,
+	http.StatusRequestEntityTooLarge: ErrContentTooLarge,
+	http.StatusUnprocessableEntity:   ErrUnprocessable,
+	http.StatusTooManyRequests:       ErrRateLimited,
+	http.StatusServiceUnavailable:    ErrServiceUnavailable,
+}
+
+// APIError represents an error response from the Directus API
+type APIError struct {
+	StatusCode int           // HTTP status code
+	Code       string        // extensions.code of the first error, if any
+	Errors     []ErrorDetail // All errors returned by the server
+	Method     string        // HTTP method of the request
+	Path       string        // Request path, without query string
+	Body       string        // Raw response body when it is not a Directus error payload
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("directus: ")
+	if e.Method != "" || e.Path != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	}
+	fmt.Fprintf(&b, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+
+	switch {
+	case len(e.Errors) > 0:
+		messages := make([]string, len(e.Errors))
+		for i, detail := range e.Errors {
+			messages[i] = detail.Message
+		}
+		b.WriteString(": ")
+		b.WriteString(strings.Join(messages, "; "))
+		if e.Code != "" {
+			fmt.Fprintf(&b, " (code: %s)", e.Code)
+		}
+	case e.Body != "":
+		b.WriteString(": ")
+		b.WriteString(e.Body)
+	}
+
+	return b.String()
+}
+
+// Is reports whether target matches one of the error's codes or its status
+// code, so a 401 with INVALID_CREDENTIALS matches both ErrInvalidCredentials
+// and ErrUnauthorized
+func (e *APIError) Is(target error) bool {
+	for _, code := range e.Codes() {
+		if errorCodes[code] == target {
+			return true
+		}
+	}
+	if statusErrors[e.StatusCode] == target {
+		return true
+	}
+	return target == ErrServer && e.StatusCode >= 500
+}
+
+// Codes returns the extensions.code of every error detail
+func (e *APIError) Codes() []string {
+	codes := make([]string, 0, len(e.Errors))
+	for _, detail := range e.Errors {
+		if code := detail.Code(); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// Code returns the extensions.code of the error detail
+func (d ErrorDetail) Code() string {
+	code, _ := d.Extensions["code"].(string)
+	return code
+}
@@ -27,7 +27,7 @@ func main() {
 	// Example 1: Using advanced filters
 	// Create complex filter using new filter builder functions
 	filter1 := directus.NewFilterEqual("status", "published")
-	filter2 := directus.NewFilterBetween("publish_date", time.Now().AddDate(0, -1, 0), time.Now())
+	filter2 := directus.NewFilterBetween("publish_date", directus.NowOffset(-1, directus.Months), directus.VarNow)
 	filter3 := directus.NewFilterNotNull("author")
 
 	// Combine filters with AND logic
@@ -0,0 +1,219 @@
+package directus
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructFields returns the Fields and Aliases that select exactly the fields
+// of the struct v decodes into, following its json tags. Nested structs,
+// slices of structs and Refs are relations and expand into paths like
+// "author.name". An optional directus tag takes comma-separated options:
+//
+//	directus:"-"                  // Not selected
+//	directus:"alias=translations" // The json name is an alias of the translations field
+//	directus:"expand"             // Select all fields of a relation decoded into a map, as "field.*"
+//	directus:"noexpand"           // Select the field as is, e.g. a JSON field decoded into a struct
+func StructFields(v interface{}) ([]string, map[string]string, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("struct fields: %v is not a struct", reflect.TypeOf(v))
+	}
+
+	selector := &fieldSelector{
+		aliases:  make(map[string]string),
+		seen:     make(map[string]bool),
+		visiting: map[reflect.Type]bool{t: true},
+	}
+	if err := selector.collect(t, ""); err != nil {
+		return nil, nil, err
+	}
+	if len(selector.aliases) == 0 {
+		return selector.fields, nil, nil
+	}
+	return selector.fields, selector.aliases, nil
+}
+
+// SetFieldsFrom sets Fields and Aliases from the struct v decodes into, see
+// StructFields
+func (qp *QueryParams) SetFieldsFrom(v interface{}) error {
+	fields, aliases, err := StructFields(v)
+	if err != nil {
+		return err
+	}
+	qp.Fields = fields
+	qp.Aliases = aliases
+	return nil
+}
+
+// fieldOptions are the options of a directus struct tag
+type fieldOptions struct {
+	skip     bool
+	alias    string
+	expand   bool
+	noexpand bool
+}
+
+// parseFieldOptions parses a directus struct tag
+func parseFieldOptions(tag string) (fieldOptions, error) {
+	var opts fieldOptions
+	if tag == "" {
+		return opts, nil
+	}
+	for _, option := range strings.Split(tag, ",") {
+		switch option = strings.TrimSpace(option); {
+		case option == "-":
+			opts.skip = true
+		case option == "expand":
+			opts.expand = true
+		case option == "noexpand":
+			opts.noexpand = true
+		case strings.HasPrefix(option, "alias="):
+			opts.alias = strings.TrimPrefix(option, "alias=")
+			if opts.alias == "" {
+				return opts, fmt.Errorf("empty alias")
+			}
+		default:
+			return opts, fmt.Errorf("unknown directus tag option %q", option)
+		}
+	}
+	if opts.expand && opts.noexpand {
+		return opts, fmt.Errorf("expand and noexpand are exclusive")
+	}
+	return opts, nil
+}
+
+// fieldSelector collects the fields of a struct and its relations
+type fieldSelector struct {
+	fields   []string
+	aliases  map[string]string
+	seen     map[string]bool
+	visiting map[reflect.Type]bool // Structs being expanded, to catch recursive relations
+}
+
+// collect adds the fields of the struct t, prefixing them with the path of
+// the relation
+func (s *fieldSelector) collect(t reflect.Type, prefix string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		// Embedded structs without a name are flattened, like encoding/json
+		// does, and are encoded even when their type is unexported
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		embeddedStruct := field.Anonymous && embedded.Kind() == reflect.Struct
+		if embeddedStruct && name == "" {
+			if err := s.collect(embedded, prefix); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() && !embeddedStruct {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		path := prefix + name
+		opts, err := parseFieldOptions(field.Tag.Get("directus"))
+		if err != nil {
+			return fmt.Errorf("struct fields: field %s: %w", path, err)
+		}
+		if opts.skip {
+			continue
+		}
+		if opts.alias != "" {
+			if prefix != "" {
+				return fmt.Errorf("struct fields: field %s: aliases are only supported on top-level fields", path)
+			}
+			if existing, ok := s.aliases[opts.alias]; ok {
+				return fmt.Errorf("struct fields: field %s: %s is already aliased as %s", path, opts.alias, existing)
+			}
+			s.aliases[opts.alias] = name
+		}
+
+		if err := s.field(field.Type, path, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field adds a field of type t, expanding relations
+func (s *fieldSelector) field(t reflect.Type, path string, opts fieldOptions) error {
+	if opts.noexpand {
+		s.add(path)
+		return nil
+	}
+
+	related, ref := relatedStruct(t)
+	if related == nil {
+		if ref || opts.expand {
+			s.add(path + ".*")
+		} else {
+			s.add(path)
+		}
+		return nil
+	}
+
+	if s.visiting[related] {
+		return fmt.Errorf(`struct fields: field %s: recursive relation to %v, tag it with directus:"noexpand"`, path, related)
+	}
+	s.visiting[related] = true
+	defer delete(s.visiting, related)
+
+	count := len(s.fields)
+	if err := s.collect(related, path+"."); err != nil {
+		return err
+	}
+	if len(s.fields) == count {
+		s.add(path + ".*")
+	}
+	return nil
+}
+
+// add appends a field once
+func (s *fieldSelector) add(field string) {
+	if !s.seen[field] {
+		s.seen[field] = true
+		s.fields = append(s.fields, field)
+	}
+}
+
+var (
+	refType         = reflect.TypeOf((*interface{ relatedType() reflect.Type })(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// relatedStruct returns the struct of the related items when t is a
+// relation: a struct, a Ref, or a pointer, slice or array of them. Structs
+// with their own JSON decoding, like time.Time, are plain fields. ref
+// reports whether t is a Ref.
+func relatedStruct(t reflect.Type) (related reflect.Type, ref bool) {
+	for {
+		switch {
+		case t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+			t = t.Elem()
+		case t.Implements(refType):
+			t = reflect.Zero(t).Interface().(interface{ relatedType() reflect.Type }).relatedType()
+			ref = true
+		case t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(unmarshalerType):
+			return t, ref
+		default:
+			return nil, ref
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package directus
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fieldsAuthor struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type fieldsTag struct {
+	Name string `json:"name"`
+}
+
+type fieldsTimestamps struct {
+	DateCreated time.Time  `json:"date_created"`
+	DateUpdated *time.Time `json:"date_updated,omitempty"`
+}
+
+type fieldsArticle struct {
+	fieldsTimestamps
+	ID        int                    `json:"id"`
+	Title     string                 `json:"title,omitempty"`
+	Body      string                 // No json tag: the Go name
+	Internal  string                 `json:"-"`
+	Cached    string                 `json:"cached" directus:"-"`
+	secret    string                 // Unexported fields are skipped
+	Author    Ref[fieldsAuthor]      `json:"author"`
+	Editor    *fieldsAuthor          `json:"editor"`
+	Tags      []fieldsTag            `json:"tags"`
+	Related   []*fieldsAuthor        `json:"related"`
+	Category  Ref[map[string]string] `json:"category"`
+	Settings  map[string]interface{} `json:"settings"`
+	Extra     map[string]interface{} `json:"extra" directus:"expand"`
+	Layout    fieldsTag              `json:"layout" directus:"noexpand"`
+	Metadata  json.RawMessage        `json:"metadata"`
+	Published time.Time              `json:"published"`
+	Empty     struct{}               `json:"empty"`
+}
+
+func TestStructFields(t *testing.T) {
+	fields, aliases, err := StructFields(&fieldsArticle{})
+	if err != nil {
+		t.Fatalf("StructFields: %v", err)
+	}
+
+	want := []string{
+		"date_created", "date_updated", // Embedded, flattened
+		"id", "title", "Body",
+		"author.id", "author.name", // Ref expands into the related struct
+		"editor.id", "editor.name", // Pointer to struct
+		"tags.name",                  // Slice of structs
+		"related.id", "related.name", // Slice of pointers
+		"category.*",            // Ref to a map
+		"settings",              // Map without expand
+		"extra.*",               // Map with expand
+		"layout",                // noexpand
+		"metadata", "published", // JSON and time fields are plain
+		"empty.*", // Relation without known fields
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields:\n%v\nwant:\n%v", fields, want)
+	}
+	if aliases != nil {
+		t.Errorf("aliases = %v, want nil", aliases)
+	}
+}
+
+func TestStructFieldsEmbedded(t *testing.T) {
+	type named struct {
+		fieldsTimestamps `json:"audit"` // Named embedded structs are relations
+		*fieldsTag                      // Embedded pointers are flattened too
+	}
+	fields, _, err := StructFields(named{})
+	if err != nil {
+		t.Fatalf("StructFields: %v", err)
+	}
+	if want := []string{"audit.date_created", "audit.date_updated", "name"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
+
+func TestStructFieldsAliases(t *testing.T) {
+	type article struct {
+		ID       int         `json:"id"`
+		English  []fieldsTag `json:"english" directus:"alias=translations"`
+		AuthorID int         `json:"author_id" directus:"alias=author"`
+	}
+
+	var qp QueryParams
+	if err := qp.SetFieldsFrom(&article{}); err != nil {
+		t.Fatalf("SetFieldsFrom: %v", err)
+	}
+	if want := []string{"id", "english.name", "author_id"}; !reflect.DeepEqual(qp.Fields, want) {
+		t.Errorf("Fields = %v, want %v", qp.Fields, want)
+	}
+	// Aliases map the original field to its alias
+	if want := map[string]string{"translations": "english", "author": "author_id"}; !reflect.DeepEqual(qp.Aliases, want) {
+		t.Errorf("Aliases = %v, want %v", qp.Aliases, want)
+	}
+
+	values := qp.Values()
+	if got := values.Get("alias[english]"); got != "translations" {
+		t.Errorf("alias[english] = %q, want translations", got)
+	}
+	if got := values.Get("alias[author_id]"); got != "author" {
+		t.Errorf("alias[author_id] = %q, want author", got)
+	}
+	if got := values.Get("alias[translations]"); got != "" {
+		t.Errorf("alias[translations] = %q, want the alias as key", got)
+	}
+}
+
+type fieldsNode struct {
+	ID     int         `json:"id"`
+	Parent *fieldsNode `json:"parent"`
+}
+
+type fieldsCycleA struct {
+	B []fieldsCycleB `json:"b"`
+}
+
+type fieldsCycleB struct {
+	A Ref[fieldsCycleA] `json:"a"`
+}
+
+func TestStructFieldsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"not a struct", map[string]interface{}{}, "is not a struct"},
+		{"nil", nil, "is not a struct"},
+		{"self relation", fieldsNode{}, `field parent: recursive relation to directus.fieldsNode, tag it with directus:"noexpand"`},
+		{"relation cycle", fieldsCycleA{}, "field b.a: recursive relation to directus.fieldsCycleA"},
+		{"unknown option", struct {
+			A string `directus:"lazy"`
+		}{}, `field A: unknown directus tag option "lazy"`},
+		{"empty alias", struct {
+			A string `directus:"alias="`
+		}{}, "field A: empty alias"},
+		{"expand and noexpand", struct {
+			A map[string]string `directus:"expand,noexpand"`
+		}{}, "field A: expand and noexpand are exclusive"},
+		{"nested alias", struct {
+			A struct {
+				B string `json:"b" directus:"alias=c"`
+			} `json:"a"`
+		}{}, "field a.b: aliases are only supported on top-level fields"},
+		{"duplicate alias", struct {
+			A string `json:"a" directus:"alias=c"`
+			B string `json:"b" directus:"alias=c"`
+		}{}, "field b: c is already aliased as a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := StructFields(tt.v)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("StructFields error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// A recursive relation selected as is
+	type node struct {
+		ID     int   `json:"id"`
+		Parent *node `json:"parent" directus:"noexpand"`
+	}
+	fields, _, err := StructFields(node{})
+	if err != nil {
+		t.Fatalf("StructFields: %v", err)
+	}
+	if want := []string{"id", "parent"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+}
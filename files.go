@@ -17,22 +17,22 @@ func NewFilesService(client *Client) *FilesService {
 }
 
 // Get retrieves a file by ID
-func (s *FilesService) Get(ctx context.Context, id string) (*File, error) {
+func (s *FilesService) Get(ctx context.Context, id string, params ...*QueryParams) (*File, error) {
 	var resp struct {
 		Data File `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "files", Name: "get", ID: id}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(fmt.Sprintf("/files/%s", id))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get file: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -44,29 +44,17 @@ func (s *FilesService) List(ctx context.Context, params *QueryParams) ([]File, e
 		Data []File `json:"data"`
 	}
 
-	req := s.client.httpClient.R().
-		SetContext(ctx).
-		SetResult(&resp)
-
-	if params != nil {
-		if len(params.Fields) > 0 {
-			req.SetQueryParam("fields", joinFields(params.Fields))
-		}
-		if params.Filter != nil {
-			req.SetQueryParam("filter", toJSONString(params.Filter))
-		}
-		if params.Limit > 0 {
-			req.SetQueryParam("limit", fmt.Sprintf("%d", params.Limit))
-		}
-	}
+	response, err := s.client.request(ctx, Operation{Service: "files", Name: "list"}).
+		SetResult(&resp).
+		SetQueryParamsFromValues(params.Values()).
+		Get("/files")
 
-	response, err := req.Get("/files")
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to list files: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return resp.Data, nil
@@ -90,8 +78,7 @@ func (s *FilesService) Upload(ctx context.Context, filePath string, metadata map
 		stringMetadata[k] = fmt.Sprintf("%v", v)
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "files", Name: "upload"}).
 		SetFile("file", filePath).
 		SetFormData(stringMetadata).
 		SetResult(&resp).
@@ -101,8 +88,8 @@ func (s *FilesService) Upload(ctx context.Context, filePath string, metadata map
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to upload file: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -114,8 +101,7 @@ func (s *FilesService) Update(ctx context.Context, id string, metadata map[strin
 		Data File `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "files", Name: "update", ID: id}).
 		SetBody(metadata).
 		SetResult(&resp).
 		Patch(fmt.Sprintf("/files/%s", id))
@@ -124,8 +110,8 @@ func (s *FilesService) Update(ctx context.Context, id string, metadata map[strin
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update file: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -133,16 +119,15 @@ func (s *FilesService) Update(ctx context.Context, id string, metadata map[strin
 
 // Delete deletes a file
 func (s *FilesService) Delete(ctx context.Context, id string) error {
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "files", Name: "delete", ID: id}).
 		Delete(fmt.Sprintf("/files/%s", id))
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != 204 {
-		return fmt.Errorf("failed to delete file: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
 	}
 
 	return nil
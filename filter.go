@@ -0,0 +1,469 @@
+package directus
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Filter is a filter built from conditions on fields, combined with And and
+// Or. Operand errors are kept until Build, so filters can be chained freely.
+// Values may be dynamic variables like VarCurrentUser or NowOffset(-7, Days),
+// and times are sent as timestamps.
+//
+//	filter, err := directus.F("status").Eq("published").
+//		And(directus.F("author.name").StartsWith("A")).
+//		Build()
+type Filter struct {
+	node interface{} // FilterCondition or LogicalFilter
+	err  error
+}
+
+// FieldFilter builds conditions on a field
+type FieldFilter struct {
+	field string
+}
+
+// F starts a condition on a field. Fields of related items are separated by
+// dots, like "author.name", and function fields like Year("date_created")
+// can be filtered on too.
+func F(field string) FieldFilter {
+	return FieldFilter{field: field}
+}
+
+// Eq matches values equal to value
+func (f FieldFilter) Eq(value interface{}) *Filter {
+	return f.condition(FilterEqual, value, checkScalar(value))
+}
+
+// Neq matches values not equal to value
+func (f FieldFilter) Neq(value interface{}) *Filter {
+	return f.condition(FilterNotEqual, value, checkScalar(value))
+}
+
+// Lt matches values less than value
+func (f FieldFilter) Lt(value interface{}) *Filter {
+	return f.condition(FilterLessThan, value, checkOrdered(value))
+}
+
+// Lte matches values less than or equal to value
+func (f FieldFilter) Lte(value interface{}) *Filter {
+	return f.condition(FilterLessThanEqual, value, checkOrdered(value))
+}
+
+// Gt matches values greater than value
+func (f FieldFilter) Gt(value interface{}) *Filter {
+	return f.condition(FilterGreaterThan, value, checkOrdered(value))
+}
+
+// Gte matches values greater than or equal to value
+func (f FieldFilter) Gte(value interface{}) *Filter {
+	return f.condition(FilterGreaterThanEq, value, checkOrdered(value))
+}
+
+// In matches values equal to one of values
+func (f FieldFilter) In(values ...interface{}) *Filter {
+	return f.condition(FilterIn, values, checkList(values))
+}
+
+// Nin matches values equal to none of values
+func (f FieldFilter) Nin(values ...interface{}) *Filter {
+	return f.condition(FilterNotIn, values, checkList(values))
+}
+
+// Between matches values between from and to, inclusive
+func (f FieldFilter) Between(from, to interface{}) *Filter {
+	return f.condition(FilterBetween, []interface{}{from, to}, checkRange(from, to))
+}
+
+// NBetween matches values outside from and to
+func (f FieldFilter) NBetween(from, to interface{}) *Filter {
+	return f.condition(FilterNotBetween, []interface{}{from, to}, checkRange(from, to))
+}
+
+// Contains matches values containing s
+func (f FieldFilter) Contains(s string) *Filter {
+	return f.condition(FilterContains, s, nil)
+}
+
+// NContains matches values not containing s
+func (f FieldFilter) NContains(s string) *Filter {
+	return f.condition(FilterNotContains, s, nil)
+}
+
+// IContains matches values containing s, ignoring case
+func (f FieldFilter) IContains(s string) *Filter {
+	return f.condition(FilterIContains, s, nil)
+}
+
+// NIContains matches values not containing s, ignoring case
+func (f FieldFilter) NIContains(s string) *Filter {
+	return f.condition(FilterNotIContains, s, nil)
+}
+
+// StartsWith matches values starting with s
+func (f FieldFilter) StartsWith(s string) *Filter {
+	return f.condition(FilterStartsWith, s, nil)
+}
+
+// NStartsWith matches values not starting with s
+func (f FieldFilter) NStartsWith(s string) *Filter {
+	return f.condition(FilterNotStartsWith, s, nil)
+}
+
+// IStartsWith matches values starting with s, ignoring case
+func (f FieldFilter) IStartsWith(s string) *Filter {
+	return f.condition(FilterIStartsWith, s, nil)
+}
+
+// NIStartsWith matches values not starting with s, ignoring case
+func (f FieldFilter) NIStartsWith(s string) *Filter {
+	return f.condition(FilterNotIStartsWith, s, nil)
+}
+
+// EndsWith matches values ending with s
+func (f FieldFilter) EndsWith(s string) *Filter {
+	return f.condition(FilterEndsWith, s, nil)
+}
+
+// NEndsWith matches values not ending with s
+func (f FieldFilter) NEndsWith(s string) *Filter {
+	return f.condition(FilterNotEndsWith, s, nil)
+}
+
+// IEndsWith matches values ending with s, ignoring case
+func (f FieldFilter) IEndsWith(s string) *Filter {
+	return f.condition(FilterIEndsWith, s, nil)
+}
+
+// NIEndsWith matches values not ending with s, ignoring case
+func (f FieldFilter) NIEndsWith(s string) *Filter {
+	return f.condition(FilterNotIEndsWith, s, nil)
+}
+
+// Regex matches values matching a regular expression. Not all databases
+// support it.
+func (f FieldFilter) Regex(pattern string) *Filter {
+	return f.condition(FilterRegex, pattern, nil)
+}
+
+// Null matches null values
+func (f FieldFilter) Null() *Filter {
+	return f.condition(FilterNull, true, nil)
+}
+
+// NNull matches values that are not null
+func (f FieldFilter) NNull() *Filter {
+	return f.condition(FilterNotNull, true, nil)
+}
+
+// Empty matches null values, empty strings and empty lists
+func (f FieldFilter) Empty() *Filter {
+	return f.condition(FilterEmpty, true, nil)
+}
+
+// NEmpty matches values that are not empty
+func (f FieldFilter) NEmpty() *Filter {
+	return f.condition(FilterNotEmpty, true, nil)
+}
+
+// Intersects matches geometries intersecting a GeoJSON geometry
+func (f FieldFilter) Intersects(geometry map[string]interface{}) *Filter {
+	return f.condition(FilterIntersects, geometry, checkGeometry(geometry))
+}
+
+// NIntersects matches geometries not intersecting a GeoJSON geometry
+func (f FieldFilter) NIntersects(geometry map[string]interface{}) *Filter {
+	return f.condition(FilterNotIntersects, geometry, checkGeometry(geometry))
+}
+
+// IntersectsBBox matches geometries intersecting the bounding box of a
+// GeoJSON geometry
+func (f FieldFilter) IntersectsBBox(geometry map[string]interface{}) *Filter {
+	return f.condition(FilterIntersectsBBox, geometry, checkGeometry(geometry))
+}
+
+// NIntersectsBBox matches geometries not intersecting the bounding box of a
+// GeoJSON geometry
+func (f FieldFilter) NIntersectsBBox(geometry map[string]interface{}) *Filter {
+	return f.condition(FilterNotIntersectsBBox, geometry, checkGeometry(geometry))
+}
+
+// Some matches items of which at least one related item of a one-to-many
+// field matches filter. Fields in filter are relative to the related items.
+func (f FieldFilter) Some(filter *Filter) *Filter {
+	return f.related(FilterSome, filter)
+}
+
+// None matches items of which no related item of a one-to-many field
+// matches filter. Fields in filter are relative to the related items.
+func (f FieldFilter) None(filter *Filter) *Filter {
+	return f.related(FilterNone, filter)
+}
+
+// related returns a condition on the related items of a field
+func (f FieldFilter) related(op FilterOperator, filter *Filter) *Filter {
+	if filter == nil {
+		return f.condition(op, nil, fmt.Errorf("nil filter"))
+	}
+	if filter.err != nil {
+		return &Filter{err: filter.err}
+	}
+	return f.condition(op, filterMap(filter.node), nil)
+}
+
+// condition returns a filter holding a condition on the field, or err
+func (f FieldFilter) condition(op FilterOperator, value interface{}, err error) *Filter {
+	if f.field == "" {
+		err = fmt.Errorf("empty field name")
+	}
+	if err != nil {
+		return &Filter{err: fmt.Errorf("filter %s on %q: %w", op, f.field, err)}
+	}
+	return &Filter{node: FilterCondition{Field: f.field, Operator: op, Value: normalizeOperand(value)}}
+}
+
+// normalizeOperand formats times as timestamps, so they serialize the same
+// way whatever their location
+func normalizeOperand(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return FormatTimestamp(v)
+	case *time.Time:
+		if v != nil {
+			return FormatTimestamp(*v)
+		}
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, element := range v {
+			normalized[i] = normalizeOperand(element)
+		}
+		return normalized
+	}
+	return value
+}
+
+// And matches items matching f and all of others
+func (f *Filter) And(others ...*Filter) *Filter {
+	return combine(LogicalAnd, append([]*Filter{f}, others...))
+}
+
+// Or matches items matching f or any of others
+func (f *Filter) Or(others ...*Filter) *Filter {
+	return combine(LogicalOr, append([]*Filter{f}, others...))
+}
+
+// And matches items matching all of filters
+func And(filters ...*Filter) *Filter {
+	return combine(LogicalAnd, filters)
+}
+
+// Or matches items matching any of filters
+func Or(filters ...*Filter) *Filter {
+	return combine(LogicalOr, filters)
+}
+
+// combine joins filters with a logical operator, flattening nested
+// combinations with the same operator
+func combine(op LogicalOperator, filters []*Filter) *Filter {
+	logical := LogicalFilter{Operator: op}
+	for _, filter := range filters {
+		if filter == nil {
+			return &Filter{err: fmt.Errorf("filter %s: nil filter", op)}
+		}
+		if filter.err != nil {
+			return filter
+		}
+		if inner, ok := filter.node.(LogicalFilter); ok && inner.Operator == op {
+			logical.Filters = append(logical.Filters, inner.Filters...)
+			continue
+		}
+		logical.Filters = append(logical.Filters, filter.node)
+	}
+
+	if len(logical.Filters) == 0 {
+		return &Filter{err: fmt.Errorf("filter %s: no filters", op)}
+	}
+	return &Filter{node: logical}
+}
+
+// Err returns the first operand error of the filter, if any
+func (f *Filter) Err() error {
+	return f.err
+}
+
+// Build compiles the filter to the form Directus expects in QueryParams.Filter
+func (f *Filter) Build() (map[string]interface{}, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return filterMap(f.node), nil
+}
+
+// SetFilter builds filter into the query parameters
+func (qp *QueryParams) SetFilter(filter *Filter) error {
+	compiled, err := filter.Build()
+	if err != nil {
+		return err
+	}
+	qp.Filter = compiled
+	return nil
+}
+
+// Map compiles the condition to the form Directus expects, nesting the
+// parts of a dotted field
+func (c FilterCondition) Map() map[string]interface{} {
+	compiled := map[string]interface{}{string(c.Operator): c.Value}
+	parts := strings.Split(c.Field, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		compiled = map[string]interface{}{parts[i]: compiled}
+	}
+	return compiled
+}
+
+// Map compiles the logical filter to the form Directus expects
+func (l LogicalFilter) Map() map[string]interface{} {
+	filters := make([]interface{}, len(l.Filters))
+	for i, filter := range l.Filters {
+		filters[i] = filterMap(filter)
+	}
+	return map[string]interface{}{string(l.Operator): filters}
+}
+
+// filterMap compiles a FilterCondition, LogicalFilter or *Filter, and
+// returns other values, such as maps, as they are
+func filterMap(v interface{}) map[string]interface{} {
+	switch v := v.(type) {
+	case FilterCondition:
+		return v.Map()
+	case *FilterCondition:
+		return v.Map()
+	case LogicalFilter:
+		return v.Map()
+	case *LogicalFilter:
+		return v.Map()
+	case *Filter:
+		return filterMap(v.node)
+	case map[string]interface{}:
+		return v
+	}
+	return nil
+}
+
+// filterOperators lists every filter operator
+var filterOperators = []FilterOperator{
+	FilterEqual, FilterNotEqual, FilterLessThan, FilterLessThanEqual, FilterGreaterThan, FilterGreaterThanEq,
+	FilterIn, FilterNotIn, FilterContains, FilterNotContains, FilterIContains, FilterNotIContains,
+	FilterStartsWith, FilterNotStartsWith, FilterIStartsWith, FilterNotIStartsWith,
+	FilterEndsWith, FilterNotEndsWith, FilterIEndsWith, FilterNotIEndsWith,
+	FilterBetween, FilterNotBetween, FilterEmpty, FilterNotEmpty, FilterNull, FilterNotNull,
+	FilterRegex, FilterIntersects, FilterNotIntersects, FilterIntersectsBBox, FilterNotIntersectsBBox,
+	FilterSome, FilterNone,
+}
+
+// isFilterOperator reports whether name is a filter operator
+func isFilterOperator(name string) bool {
+	return slices.Contains(filterOperators, FilterOperator(name))
+}
+
+// operandKind classifies operand values for validation
+type operandKind int
+
+const (
+	kindInvalid operandKind = iota
+	kindNull
+	kindBool
+	kindNumber
+	kindString
+	kindTime
+)
+
+// kindOf returns the kind of an operand value
+func kindOf(v interface{}) operandKind {
+	switch v.(type) {
+	case nil:
+		return kindNull
+	case time.Time, *time.Time:
+		return kindTime
+	case json.Number:
+		return kindNumber
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return kindNull
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return kindNumber
+	case reflect.String:
+		return kindString
+	}
+	return kindInvalid
+}
+
+// checkScalar validates an operand compared for equality
+func checkScalar(v interface{}) error {
+	if kindOf(v) == kindInvalid {
+		return fmt.Errorf("operand must be a string, number, bool, time or nil, got %T", v)
+	}
+	return nil
+}
+
+// checkOrdered validates an operand compared for order
+func checkOrdered(v interface{}) error {
+	switch kindOf(v) {
+	case kindNumber, kindString, kindTime:
+		return nil
+	}
+	return fmt.Errorf("operand must be a string, number or time, got %T", v)
+}
+
+// checkList validates the operands of In and Nin
+func checkList(values []interface{}) error {
+	if len(values) == 0 {
+		return fmt.Errorf("no values")
+	}
+	for _, v := range values {
+		if err := checkScalar(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRange validates the bounds of Between and NBetween
+func checkRange(from, to interface{}) error {
+	if err := checkOrdered(from); err != nil {
+		return err
+	}
+	if err := checkOrdered(to); err != nil {
+		return err
+	}
+
+	// Dates may be given as strings or as times
+	fromKind, toKind := kindOf(from), kindOf(to)
+	if fromKind != toKind && (fromKind == kindNumber || toKind == kindNumber) {
+		return fmt.Errorf("bounds must have the same type, got %T and %T", from, to)
+	}
+	return nil
+}
+
+// checkGeometry validates a GeoJSON geometry operand
+func checkGeometry(geometry map[string]interface{}) error {
+	if _, ok := geometry["type"].(string); !ok {
+		return fmt.Errorf("geometry must be a GeoJSON object with a type")
+	}
+	return nil
+}
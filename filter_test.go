@@ -0,0 +1,163 @@
+package directus
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// buildJSON builds filter and encodes it to JSON
+func buildJSON(t *testing.T, filter *Filter) string {
+	t.Helper()
+	compiled, err := filter.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	data, err := json.Marshal(compiled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFilterBuild(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	created := time.Date(2024, 3, 15, 11, 30, 45, 500_000_000, berlin)
+	point := map[string]interface{}{"type": "Point", "coordinates": []interface{}{1, 2}}
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   string
+	}{
+		{"eq", F("status").Eq("published"), `{"status":{"_eq":"published"}}`},
+		{"eq null", F("deleted_at").Eq(nil), `{"deleted_at":{"_eq":null}}`},
+		{"neq", F("views").Neq(0), `{"views":{"_neq":0}}`},
+		{"lt", F("views").Lt(10), `{"views":{"_lt":10}}`},
+		{"lte", F("views").Lte(10.5), `{"views":{"_lte":10.5}}`},
+		{"gt", F("title").Gt("m"), `{"title":{"_gt":"m"}}`},
+		{"gte", F("views").Gte(json.Number("3")), `{"views":{"_gte":3}}`},
+		{"in", F("id").In(1, 2, 3), `{"id":{"_in":[1,2,3]}}`},
+		{"nin", F("status").Nin("draft"), `{"status":{"_nin":["draft"]}}`},
+		{"between", F("views").Between(1, 10), `{"views":{"_between":[1,10]}}`},
+		{"nbetween", F("date").NBetween("2024-01-01", created), `{"date":{"_nbetween":["2024-01-01","2024-03-15T10:30:45.500Z"]}}`},
+		{"contains", F("title").Contains("go"), `{"title":{"_contains":"go"}}`},
+		{"nicontains", F("title").NIContains("Go"), `{"title":{"_nicontains":"Go"}}`},
+		{"starts_with", F("title").StartsWith("A"), `{"title":{"_starts_with":"A"}}`},
+		{"niends_with", F("title").NIEndsWith("z"), `{"title":{"_niends_with":"z"}}`},
+		{"regex", F("title").Regex("^A"), `{"title":{"_regex":"^A"}}`},
+		{"null", F("deleted_at").Null(), `{"deleted_at":{"_null":true}}`},
+		{"nnull", F("deleted_at").NNull(), `{"deleted_at":{"_nnull":true}}`},
+		{"empty", F("tags").Empty(), `{"tags":{"_empty":true}}`},
+		{"nempty", F("tags").NEmpty(), `{"tags":{"_nempty":true}}`},
+		{"intersects", F("location").Intersects(point), `{"location":{"_intersects":{"coordinates":[1,2],"type":"Point"}}}`},
+		{"nintersects_bbox", F("location").NIntersectsBBox(point), `{"location":{"_nintersects_bbox":{"coordinates":[1,2],"type":"Point"}}}`},
+
+		// Dotted fields nest, function fields do not
+		{"dotted", F("author.role.name").Eq("Admin"), `{"author":{"role":{"name":{"_eq":"Admin"}}}}`},
+		{"function", F(Year("date_created")).Eq(2024), `{"year(date_created)":{"_eq":2024}}`},
+		{"dotted function", F("author." + Month("birthday")).Eq(3), `{"author":{"month(birthday)":{"_eq":3}}}`},
+
+		// Times are sent as UTC timestamps, variables as they are
+		{"time", F("date_created").Gt(created), `{"date_created":{"_gt":"2024-03-15T10:30:45.500Z"}}`},
+		{"time pointer", F("date_created").Lt(&created), `{"date_created":{"_lt":"2024-03-15T10:30:45.500Z"}}`},
+		{"time in", F("date").In(created, "2024-01-01"), `{"date":{"_in":["2024-03-15T10:30:45.500Z","2024-01-01"]}}`},
+		{"time between", F("date").Between(created, created.Add(time.Hour)), `{"date":{"_between":["2024-03-15T10:30:45.500Z","2024-03-15T11:30:45.500Z"]}}`},
+		{"variable", F("owner").Eq(VarCurrentUser), `{"owner":{"_eq":"$CURRENT_USER"}}`},
+		{"now offset", F("date_created").Gte(NowOffset(-7, Days)), `{"date_created":{"_gte":"$NOW(-7 days)"}}`},
+
+		// Related items
+		{"some", F("tags").Some(F("name").Eq("go")), `{"tags":{"_some":{"name":{"_eq":"go"}}}}`},
+		{"none", F("comments").None(F("approved").Eq(false).And(F("spam").Eq(true))), `{"comments":{"_none":{"_and":[{"approved":{"_eq":false}},{"spam":{"_eq":true}}]}}}`},
+		{"some dotted", F("author.posts").Some(F("status").Eq("draft")), `{"author":{"posts":{"_some":{"status":{"_eq":"draft"}}}}}`},
+
+		// Logical operators
+		{"and", F("a").Eq(1).And(F("b").Eq(2)), `{"_and":[{"a":{"_eq":1}},{"b":{"_eq":2}}]}`},
+		{"or", Or(F("a").Eq(1), F("b").Eq(2)), `{"_or":[{"a":{"_eq":1}},{"b":{"_eq":2}}]}`},
+		{"and flattened", F("a").Eq(1).And(F("b").Eq(2)).And(F("c").Eq(3), And(F("d").Eq(4), F("e").Eq(5))), `{"_and":[{"a":{"_eq":1}},{"b":{"_eq":2}},{"c":{"_eq":3}},{"d":{"_eq":4}},{"e":{"_eq":5}}]}`},
+		{"or flattened", Or(Or(F("a").Eq(1), F("b").Eq(2)), F("c").Eq(3)), `{"_or":[{"a":{"_eq":1}},{"b":{"_eq":2}},{"c":{"_eq":3}}]}`},
+		{"mixed not flattened", And(F("a").Eq(1), Or(F("b").Eq(2), F("c").Eq(3))), `{"_and":[{"a":{"_eq":1}},{"_or":[{"b":{"_eq":2}},{"c":{"_eq":3}}]}]}`},
+		{"single", And(F("a").Eq(1)), `{"_and":[{"a":{"_eq":1}}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildJSON(t, tt.filter); got != tt.want {
+				t.Errorf("Build = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	type custom struct{}
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   string
+	}{
+		{"empty field", F("").Eq(1), `filter _eq on "": empty field name`},
+		{"eq struct", F("a").Eq(custom{}), `filter _eq on "a": operand must be a string, number, bool, time or nil, got directus.custom`},
+		{"eq map", F("a").Eq(map[string]interface{}{}), "operand must be a string, number, bool, time or nil"},
+		{"gt bool", F("a").Gt(true), `filter _gt on "a": operand must be a string, number or time, got bool`},
+		{"lt nil", F("a").Lt(nil), "operand must be a string, number or time, got <nil>"},
+		{"in empty", F("a").In(), `filter _in on "a": no values`},
+		{"nin slice", F("a").Nin([]int{1, 2}), "got []int"},
+		{"between mixed", F("a").Between(1, "z"), "bounds must have the same type, got int and string"},
+		{"between bool", F("a").NBetween(false, true), "operand must be a string, number or time, got bool"},
+		{"geometry", F("a").Intersects(map[string]interface{}{"coordinates": []interface{}{1, 2}}), "geometry must be a GeoJSON object with a type"},
+		{"some nil", F("tags").Some(nil), `filter _some on "tags": nil filter`},
+		{"none invalid", F("tags").None(F("name").Gt(true)), `filter _gt on "name"`},
+		{"and nil", And(F("a").Eq(1), nil), "filter _and: nil filter"},
+		{"or empty", Or(), "filter _or: no filters"},
+
+		// Errors survive chaining until Build
+		{"chained left", F("a").Gt(true).And(F("b").Eq(1)).Or(F("c").Eq(2)), `filter _gt on "a"`},
+		{"chained right", F("a").Eq(1).And(F("b").Eq(1), F("c").In()).Or(F("d").Eq(2)), `filter _in on "c"`},
+		{"first error wins", And(F("a").Lt(nil), F("b").In()), `filter _lt on "a"`},
+		{"nested some", And(F("a").Eq(1), F("tags").Some(F("x").Eq(custom{}))), `filter _eq on "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Err(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Err = %v, want %q", err, tt.want)
+			}
+			compiled, err := tt.filter.Build()
+			if err == nil || compiled != nil {
+				t.Errorf("Build = %v, %v, want an error", compiled, err)
+			}
+
+			var qp QueryParams
+			if err := qp.SetFilter(tt.filter); err == nil || qp.Filter != nil {
+				t.Errorf("SetFilter = %v with Filter %v, want an error and no filter", err, qp.Filter)
+			}
+		})
+	}
+}
+
+func TestFilterConditionMap(t *testing.T) {
+	tests := []struct {
+		condition FilterCondition
+		want      string
+	}{
+		{FilterCondition{Field: "title", Operator: FilterEqual, Value: "x"}, `{"title":{"_eq":"x"}}`},
+		{FilterCondition{Field: "author.name", Operator: FilterContains, Value: "A"}, `{"author":{"name":{"_contains":"A"}}}`},
+		{FilterCondition{Field: "a.b.c.d", Operator: FilterNull, Value: true}, `{"a":{"b":{"c":{"d":{"_null":true}}}}}`},
+	}
+	for _, tt := range tests {
+		data, _ := json.Marshal(tt.condition.Map())
+		if string(data) != tt.want {
+			t.Errorf("%s Map = %s, want %s", tt.condition.Field, data, tt.want)
+		}
+	}
+
+	logical := LogicalFilter{Operator: LogicalOr, Filters: []interface{}{
+		FilterCondition{Field: "a.b", Operator: FilterEqual, Value: 1},
+		&LogicalFilter{Operator: LogicalAnd, Filters: []interface{}{map[string]interface{}{"c": map[string]interface{}{"_eq": 2}}}},
+	}}
+	data, _ := json.Marshal(logical.Map())
+	if want := `{"_or":[{"a":{"b":{"_eq":1}}},{"_and":[{"c":{"_eq":2}}]}]}`; string(data) != want {
+		t.Errorf("LogicalFilter Map = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,920 @@
+package directus
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterVars holds the values of dynamic variables for local filter
+// evaluation
+type FilterVars struct {
+	Now      time.Time      // $NOW. Defaults to the time of evaluation
+	User     interface{}    // $CURRENT_USER: a key, or an Item whose fields $CURRENT_USER.field reads
+	Role     interface{}    // $CURRENT_ROLE: a key, or an Item whose fields $CURRENT_ROLE.field reads
+	Policies []interface{}  // $CURRENT_POLICIES
+	Location *time.Location // Time zone of dates and datetimes without offset. Defaults to UTC
+}
+
+// MatchFilter reports whether item matches a filter, following the
+// semantics Directus applies in the database: a condition other than
+// _null, _nnull, _empty and _nempty never matches a null value, conditions
+// on a one-to-many field match when some related item does, and relations
+// must be expanded in item to be filtered on. vars may be nil.
+func MatchFilter(filter map[string]interface{}, item Item, vars *FilterVars) (bool, error) {
+	e := newFilterEvaluator(vars)
+	return e.match(filter, map[string]interface{}(item))
+}
+
+// FilterItems returns the items matching a filter
+func FilterItems(items []Item, filter map[string]interface{}, vars *FilterVars) ([]Item, error) {
+	e := newFilterEvaluator(vars)
+
+	var matched []Item
+	for i, item := range items {
+		ok, err := e.match(filter, map[string]interface{}(item))
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+	return matched, nil
+}
+
+// filterEvaluator evaluates filters with fixed variables
+type filterEvaluator struct {
+	vars FilterVars
+}
+
+// newFilterEvaluator returns an evaluator with the defaults of vars filled in
+func newFilterEvaluator(vars *FilterVars) *filterEvaluator {
+	e := &filterEvaluator{}
+	if vars != nil {
+		e.vars = *vars
+	}
+	if e.vars.Now.IsZero() {
+		e.vars.Now = time.Now()
+	}
+	if e.vars.Location == nil {
+		e.vars.Location = time.UTC
+	}
+	return e
+}
+
+// match reports whether the fields of an item match every key of filter
+func (e *filterEvaluator) match(filter map[string]interface{}, item map[string]interface{}) (bool, error) {
+	for key, value := range filter {
+		var ok bool
+		var err error
+		switch key {
+		case string(LogicalAnd), string(LogicalOr):
+			ok, err = e.matchLogical(LogicalOperator(key), value, item)
+		default:
+			ok, err = e.matchField(key, value, item)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchLogical evaluates the operands of _and or _or
+func (e *filterEvaluator) matchLogical(op LogicalOperator, value interface{}, item map[string]interface{}) (bool, error) {
+	operands, ok := toList(value)
+	if !ok {
+		return false, fmt.Errorf("%s must be a list, got %T", op, value)
+	}
+
+	for _, operand := range operands {
+		filter := filterMap(operand)
+		if filter == nil {
+			return false, fmt.Errorf("invalid %s operand %T", op, operand)
+		}
+		ok, err := e.match(filter, item)
+		if err != nil {
+			return false, err
+		}
+		if ok == (op == LogicalOr) {
+			return ok, nil
+		}
+	}
+	return op == LogicalAnd, nil
+}
+
+// matchField evaluates the conditions under a field of item
+func (e *filterEvaluator) matchField(field string, value interface{}, item map[string]interface{}) (bool, error) {
+	conditions, ok := value.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("expected operators under %q, got %T", field, value)
+	}
+
+	fieldValue, err := e.fieldValue(field, item)
+	if err != nil {
+		return false, err
+	}
+
+	for key, operand := range conditions {
+		var ok bool
+		switch {
+		case key == string(FilterSome) || key == string(FilterNone):
+			if fieldValue == nil {
+				// No related items
+				ok = key == string(FilterNone)
+				break
+			}
+			ok, err = e.matchRelated(field, FilterOperator(key), operand, fieldValue)
+		case isFilterOperator(key):
+			ok, err = e.matchOperator(FilterOperator(key), fieldValue, operand)
+			if err != nil {
+				err = fmt.Errorf("%s on %q: %w", key, field, err)
+			}
+		default:
+			// A nested field of a related item; one-to-many relations match
+			// when some related item does
+			ok, err = e.matchRelated(field, FilterSome, map[string]interface{}{key: operand}, fieldValue)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchRelated evaluates a filter on the related items of a field
+func (e *filterEvaluator) matchRelated(field string, op FilterOperator, operand, related interface{}) (bool, error) {
+	filter := filterMap(operand)
+	if filter == nil {
+		return false, fmt.Errorf("%s on %q: expected a filter, got %T", op, field, operand)
+	}
+
+	var items []interface{}
+	switch related := related.(type) {
+	case nil:
+		// A null relation joins a row of nulls
+		items = []interface{}{map[string]interface{}{}}
+	case map[string]interface{}, Item:
+		items = []interface{}{related}
+	default:
+		list, ok := toList(related)
+		if !ok {
+			return false, fmt.Errorf("relation %q is not expanded; include its fields in Fields", field)
+		}
+		items = list
+	}
+
+	for _, related := range items {
+		var fields map[string]interface{}
+		switch related := related.(type) {
+		case map[string]interface{}:
+			fields = related
+		case Item:
+			fields = related
+		default:
+			return false, fmt.Errorf("relation %q is not expanded; include its fields in Fields", field)
+		}
+
+		ok, err := e.match(filter, fields)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return op == FilterSome, nil
+		}
+	}
+	return op == FilterNone, nil
+}
+
+// functionField matches function fields like year(date_created)
+var functionField = regexp.MustCompile(`^(\w+)\((\w+)\)$`)
+
+// fieldValue returns the value of a field of item, computing function fields
+func (e *filterEvaluator) fieldValue(field string, item map[string]interface{}) (interface{}, error) {
+	match := functionField.FindStringSubmatch(field)
+	if match == nil {
+		return item[field], nil
+	}
+
+	fn, value := match[1], item[match[2]]
+	if fn == "count" {
+		if value == nil {
+			return 0, nil
+		}
+		list, ok := toList(value)
+		if !ok {
+			return nil, fmt.Errorf("count(%s): relation is not expanded", match[2])
+		}
+		return len(list), nil
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+	t, ok := e.toTime(value)
+	if !ok {
+		return nil, fmt.Errorf("%s: %v is not a date", field, value)
+	}
+	switch fn {
+	case "year":
+		return t.Year(), nil
+	case "month":
+		return int(t.Month()), nil
+	case "week":
+		_, week := t.ISOWeek()
+		return week, nil
+	case "day":
+		return t.Day(), nil
+	case "weekday":
+		return int(t.Weekday()), nil
+	case "hour":
+		return t.Hour(), nil
+	case "minute":
+		return t.Minute(), nil
+	case "second":
+		return t.Second(), nil
+	}
+	return nil, fmt.Errorf("unknown function %q", fn)
+}
+
+// matchOperator applies an operator to a field value
+func (e *filterEvaluator) matchOperator(op FilterOperator, value, operand interface{}) (bool, error) {
+	operand, err := e.resolve(operand)
+	if err != nil {
+		return false, err
+	}
+
+	switch op {
+	case FilterNull, FilterNotNull:
+		// _null: false and _nnull: false invert the condition, as in Directus
+		return (value == nil) == (truthy(operand) == (op == FilterNull)), nil
+	case FilterEmpty, FilterNotEmpty:
+		return isEmptyValue(value) == (truthy(operand) == (op == FilterEmpty)), nil
+	case FilterEqual:
+		if operand == nil {
+			return value == nil, nil
+		}
+	case FilterNotEqual:
+		if operand == nil {
+			return value != nil, nil
+		}
+	}
+
+	// Like SQL, every other comparison with null is false
+	if value == nil {
+		return false, nil
+	}
+
+	switch op {
+	case FilterEqual:
+		return e.equal(value, operand), nil
+	case FilterNotEqual:
+		return !e.equal(value, operand), nil
+
+	case FilterLessThan, FilterLessThanEqual, FilterGreaterThan, FilterGreaterThanEq:
+		c, ok := e.compare(value, operand)
+		if !ok {
+			return false, fmt.Errorf("cannot compare %T with %T", value, operand)
+		}
+		switch op {
+		case FilterLessThan:
+			return c < 0, nil
+		case FilterLessThanEqual:
+			return c <= 0, nil
+		case FilterGreaterThan:
+			return c > 0, nil
+		}
+		return c >= 0, nil
+
+	case FilterIn, FilterNotIn:
+		values, err := e.resolveList(operand)
+		if err != nil {
+			return false, err
+		}
+		found := false
+		for _, v := range values {
+			if e.equal(value, v) {
+				found = true
+				break
+			}
+		}
+		return found == (op == FilterIn), nil
+
+	case FilterBetween, FilterNotBetween:
+		bounds, err := e.resolveList(operand)
+		if err != nil {
+			return false, err
+		}
+		if len(bounds) != 2 {
+			return false, fmt.Errorf("expected two bounds, got %d", len(bounds))
+		}
+		low, ok1 := e.compare(value, bounds[0])
+		high, ok2 := e.compare(value, bounds[1])
+		if !ok1 || !ok2 {
+			return false, fmt.Errorf("cannot compare %T with %T and %T", value, bounds[0], bounds[1])
+		}
+		return (low >= 0 && high <= 0) == (op == FilterBetween), nil
+
+	case FilterContains, FilterNotContains, FilterIContains, FilterNotIContains,
+		FilterStartsWith, FilterNotStartsWith, FilterIStartsWith, FilterNotIStartsWith,
+		FilterEndsWith, FilterNotEndsWith, FilterIEndsWith, FilterNotIEndsWith:
+		return matchString(op, toString(value), toString(operand)), nil
+
+	case FilterRegex:
+		pattern, ok := operand.(string)
+		if !ok {
+			return false, fmt.Errorf("expected a pattern, got %T", operand)
+		}
+		re, err := regexp.Compile(strings.TrimSuffix(strings.TrimPrefix(pattern, "/"), "/"))
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(toString(value)), nil
+
+	case FilterIntersects, FilterNotIntersects, FilterIntersectsBBox, FilterNotIntersectsBBox:
+		a, err := parseGeometry(value)
+		if err != nil {
+			return false, err
+		}
+		b, err := parseGeometry(operand)
+		if err != nil {
+			return false, err
+		}
+		var intersects bool
+		if op == FilterIntersectsBBox || op == FilterNotIntersectsBBox {
+			intersects = a.bbox().intersects(b.bbox())
+		} else {
+			intersects = a.intersects(b)
+		}
+		return intersects == (op == FilterIntersects || op == FilterIntersectsBBox), nil
+	}
+
+	return false, fmt.Errorf("unsupported operator")
+}
+
+// matchString applies a string operator
+func matchString(op FilterOperator, value, operand string) bool {
+	// Operators are named [n][i]contains, [n][i]starts_with and [n][i]ends_with
+	name := strings.TrimPrefix(string(op), "_")
+	name, negate := strings.CutPrefix(name, "n")
+	name, insensitive := strings.CutPrefix(name, "i")
+	if insensitive {
+		value, operand = strings.ToLower(value), strings.ToLower(operand)
+	}
+
+	var matched bool
+	switch name {
+	case "contains":
+		matched = strings.Contains(value, operand)
+	case "starts_with":
+		matched = strings.HasPrefix(value, operand)
+	case "ends_with":
+		matched = strings.HasSuffix(value, operand)
+	}
+	return matched != negate
+}
+
+// resolve replaces a dynamic variable by its value
+func (e *filterEvaluator) resolve(operand interface{}) (interface{}, error) {
+	if v, ok := operand.(Variable); ok {
+		operand = string(v)
+	}
+	s, ok := operand.(string)
+	if !ok || !strings.HasPrefix(s, "$") {
+		return operand, nil
+	}
+
+	name, path, _ := strings.Cut(s, ".")
+	switch {
+	case name == "$NOW" || strings.HasPrefix(name, "$NOW("):
+		return resolveNow(s, e.vars.Now)
+	case name == "$CURRENT_USER":
+		return variableField(s, e.vars.User, path)
+	case name == "$CURRENT_ROLE":
+		return variableField(s, e.vars.Role, path)
+	case name == "$CURRENT_POLICIES":
+		return e.vars.Policies, nil
+	}
+	return operand, nil
+}
+
+// resolveList resolves the variables in a list operand. Directus also
+// accepts lists as comma separated strings.
+func (e *filterEvaluator) resolveList(operand interface{}) ([]interface{}, error) {
+	var list []interface{}
+	if s, ok := operand.(string); ok {
+		for _, part := range strings.Split(s, ",") {
+			list = append(list, part)
+		}
+	} else if list, ok = toList(operand); !ok {
+		return nil, fmt.Errorf("expected a list, got %T", operand)
+	}
+
+	values := make([]interface{}, 0, len(list))
+	for _, v := range list {
+		resolved, err := e.resolve(v)
+		if err != nil {
+			return nil, err
+		}
+		// $CURRENT_POLICIES expands to several values
+		if nested, ok := resolved.([]interface{}); ok {
+			values = append(values, nested...)
+		} else {
+			values = append(values, resolved)
+		}
+	}
+	return values, nil
+}
+
+// resolveNow returns the time of a $NOW variable, adjusted by its offset
+// like $NOW(-7 days)
+func resolveNow(variable string, now time.Time) (time.Time, error) {
+	offset, ok := strings.CutPrefix(variable, "$NOW(")
+	if !ok {
+		return now, nil
+	}
+	offset = strings.TrimSuffix(offset, ")")
+	return adjustTime(now, offset)
+}
+
+// adjustTime moves t by an offset like "-7 days" or "+1 year"
+func adjustTime(t time.Time, offset string) (time.Time, error) {
+	fields := strings.Fields(offset)
+	if len(fields) == 1 {
+		// "-7days" without a space
+		i := strings.IndexFunc(fields[0], func(r rune) bool { return r >= 'a' && r <= 'z' })
+		if i > 0 {
+			fields = []string{fields[0][:i], fields[0][i:]}
+		}
+	}
+	if len(fields) != 2 {
+		return t, fmt.Errorf("invalid $NOW offset %q", offset)
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+	if err != nil {
+		return t, fmt.Errorf("invalid $NOW offset %q", offset)
+	}
+
+	switch strings.TrimSuffix(strings.ToLower(fields[1]), "s") {
+	case "year":
+		return t.AddDate(n, 0, 0), nil
+	case "month":
+		return t.AddDate(0, n, 0), nil
+	case "week":
+		return t.AddDate(0, 0, 7*n), nil
+	case "day":
+		return t.AddDate(0, 0, n), nil
+	case "hour":
+		return t.Add(time.Duration(n) * time.Hour), nil
+	case "minute":
+		return t.Add(time.Duration(n) * time.Minute), nil
+	case "second":
+		return t.Add(time.Duration(n) * time.Second), nil
+	case "millisecond":
+		return t.Add(time.Duration(n) * time.Millisecond), nil
+	}
+	return t, fmt.Errorf("invalid $NOW offset unit %q", fields[1])
+}
+
+// variableField returns the value of $CURRENT_USER or $CURRENT_ROLE, or of
+// one of its fields
+func variableField(variable string, value interface{}, path string) (interface{}, error) {
+	if value == nil {
+		return nil, fmt.Errorf("%s is not set", variable)
+	}
+
+	fields, isItem := value.(map[string]interface{})
+	if item, ok := value.(Item); ok {
+		fields, isItem = item, true
+	}
+	if path == "" {
+		if isItem {
+			return fields["id"], nil
+		}
+		return value, nil
+	}
+
+	if !isItem {
+		return nil, fmt.Errorf("%s needs an Item to read its fields", variable)
+	}
+	for _, key := range strings.Split(path, ".") {
+		next, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("%s: no field %q", variable, key)
+		}
+		if nested, ok := next.(map[string]interface{}); ok {
+			fields = nested
+		}
+		value = next
+	}
+	return value, nil
+}
+
+// equal compares values the way the database would
+func (e *filterEvaluator) equal(a, b interface{}) bool {
+	if c, ok := e.compare(a, b); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare orders two values: numbers numerically, dates chronologically
+// and strings lexically
+func (e *filterEvaluator) compare(a, b interface{}) (int, bool) {
+	_, aTime := a.(time.Time)
+	_, bTime := b.(time.Time)
+	if aTime || bTime {
+		ta, ok1 := e.toTime(a)
+		tb, ok2 := e.toTime(b)
+		return ta.Compare(tb), ok1 && ok2
+	}
+
+	if isNumber(a) || isNumber(b) {
+		fa, ok1 := toFloat(a)
+		fb, ok2 := toFloat(b)
+		if !ok1 || !ok2 {
+			return 0, false
+		}
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			return strings.Compare(sa, sb), true
+		}
+	}
+
+	if ba, ok := a.(bool); ok {
+		if bb, ok := b.(bool); ok {
+			switch {
+			case ba == bb:
+				return 0, true
+			case bb:
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+
+	return 0, false
+}
+
+// dateLayouts are the formats Directus returns dates, datetimes and
+// timestamps in
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05",
+}
+
+// toTime converts a time or a date string to a time
+func (e *filterEvaluator) toTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range dateLayouts {
+			if t, err := time.ParseInLocation(layout, v, e.vars.Location); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// isNumber reports whether v has a numeric type
+func isNumber(v interface{}) bool {
+	if _, ok := v.(json.Number); ok {
+		return true
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// toFloat converts a number or a numeric string to a float64. Databases
+// return decimals and big integers as strings.
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// toString formats a value for string operators
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// toList converts any slice to []interface{}
+func toList(v interface{}) ([]interface{}, bool) {
+	if list, ok := v.([]interface{}); ok {
+		return list, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, true
+}
+
+// truthy reports whether an operand like the true of _null: true is set
+func truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v != "false" && v != "0" && v != ""
+	case nil:
+		return false
+	}
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+	return true
+}
+
+// isEmptyValue reports whether a value is null, an empty string or an
+// empty list
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	if list, ok := toList(v); ok {
+		return len(list) == 0
+	}
+	return false
+}
+
+// geometry is a GeoJSON geometry flattened to points, line segments and
+// polygon rings
+type geometry struct {
+	points   [][2]float64
+	segments [][2][2]float64
+	polygons [][][][2]float64 // Rings of each polygon, the first being the exterior
+}
+
+// boundingBox is the bounding box of a geometry
+type boundingBox struct {
+	min, max [2]float64
+}
+
+// parseGeometry flattens a GeoJSON geometry
+func parseGeometry(v interface{}) (*geometry, error) {
+	if s, ok := v.(string); ok {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+		}
+		v = decoded
+	}
+
+	var object map[string]interface{}
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = json.Unmarshal(data, &object)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+
+	g := &geometry{}
+	if err := g.add(object); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// add flattens a GeoJSON object into g
+func (g *geometry) add(object map[string]interface{}) error {
+	kind, _ := object["type"].(string)
+	if kind == "GeometryCollection" {
+		geometries, _ := object["geometries"].([]interface{})
+		for _, child := range geometries {
+			childObject, ok := child.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid GeoJSON geometry")
+			}
+			if err := g.add(childObject); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(object["coordinates"])
+	if err != nil {
+		return fmt.Errorf("invalid GeoJSON coordinates: %w", err)
+	}
+
+	var single [2]float64
+	var lines [][][2]float64
+	var polygons [][][][2]float64
+	switch kind {
+	case "Point":
+		err = json.Unmarshal(data, &single)
+		g.points = append(g.points, single)
+	case "MultiPoint":
+		var points [][2]float64
+		err = json.Unmarshal(data, &points)
+		g.points = append(g.points, points...)
+	case "LineString":
+		var line [][2]float64
+		err = json.Unmarshal(data, &line)
+		lines = [][][2]float64{line}
+	case "MultiLineString", "Polygon":
+		err = json.Unmarshal(data, &lines)
+		if kind == "Polygon" {
+			polygons = [][][][2]float64{lines}
+		}
+	case "MultiPolygon":
+		err = json.Unmarshal(data, &polygons)
+		for _, rings := range polygons {
+			lines = append(lines, rings...)
+		}
+	default:
+		return fmt.Errorf("unsupported GeoJSON type %q", kind)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid GeoJSON coordinates: %w", err)
+	}
+
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			g.segments = append(g.segments, [2][2]float64{line[i-1], line[i]})
+		}
+	}
+	g.polygons = append(g.polygons, polygons...)
+	return nil
+}
+
+// vertices returns all points of g, including segment ends
+func (g *geometry) vertices() [][2]float64 {
+	vertices := append([][2]float64{}, g.points...)
+	for _, s := range g.segments {
+		vertices = append(vertices, s[0], s[1])
+	}
+	return vertices
+}
+
+// bbox returns the bounding box of g
+func (g *geometry) bbox() boundingBox {
+	box := boundingBox{min: [2]float64{math.Inf(1), math.Inf(1)}, max: [2]float64{math.Inf(-1), math.Inf(-1)}}
+	for _, p := range g.vertices() {
+		for i := 0; i < 2; i++ {
+			box.min[i] = math.Min(box.min[i], p[i])
+			box.max[i] = math.Max(box.max[i], p[i])
+		}
+	}
+	return box
+}
+
+// intersects reports whether two bounding boxes overlap
+func (b boundingBox) intersects(other boundingBox) bool {
+	return b.min[0] <= other.max[0] && other.min[0] <= b.max[0] &&
+		b.min[1] <= other.max[1] && other.min[1] <= b.max[1]
+}
+
+// intersects reports whether two geometries share a point, in planar
+// coordinates
+func (g *geometry) intersects(other *geometry) bool {
+	// Points are segments of length zero
+	segments := func(g *geometry) [][2][2]float64 {
+		all := append([][2][2]float64{}, g.segments...)
+		for _, p := range g.points {
+			all = append(all, [2][2]float64{p, p})
+		}
+		return all
+	}
+
+	for _, a := range segments(g) {
+		for _, b := range segments(other) {
+			if segmentsIntersect(a, b) {
+				return true
+			}
+		}
+	}
+
+	// Without crossing boundaries, one geometry may lie inside the other
+	for _, p := range g.vertices() {
+		if other.contains(p) {
+			return true
+		}
+	}
+	for _, p := range other.vertices() {
+		if g.contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether a point lies inside one of the polygons of g
+func (g *geometry) contains(p [2]float64) bool {
+	for _, rings := range g.polygons {
+		if len(rings) == 0 || !ringContains(rings[0], p) {
+			continue
+		}
+		inHole := false
+		for _, hole := range rings[1:] {
+			if ringContains(hole, p) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains reports whether a point lies inside a ring, by ray casting
+func ringContains(ring [][2]float64, p [2]float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > p[1]) != (b[1] > p[1]) &&
+			p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// segmentsIntersect reports whether two line segments share a point
+func segmentsIntersect(a, b [2][2]float64) bool {
+	d1 := orientation(b[0], b[1], a[0])
+	d2 := orientation(b[0], b[1], a[1])
+	d3 := orientation(a[0], a[1], b[0])
+	d4 := orientation(a[0], a[1], b[1])
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(b, a[0])) || (d2 == 0 && onSegment(b, a[1])) ||
+		(d3 == 0 && onSegment(a, b[0])) || (d4 == 0 && onSegment(a, b[1]))
+}
+
+// orientation returns the sign of the turn from a to b to c
+func orientation(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// onSegment reports whether a point collinear with a segment lies on it
+func onSegment(s [2][2]float64, p [2]float64) bool {
+	return math.Min(s[0][0], s[1][0]) <= p[0] && p[0] <= math.Max(s[0][0], s[1][0]) &&
+		math.Min(s[0][1], s[1][1]) <= p[1] && p[1] <= math.Max(s[0][1], s[1][1])
+}
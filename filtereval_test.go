@@ -0,0 +1,301 @@
+package directus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// square returns a GeoJSON polygon ring of a square
+func square(x0, y0, x1, y1 float64) []interface{} {
+	return []interface{}{
+		[]interface{}{x0, y0}, []interface{}{x1, y0}, []interface{}{x1, y1}, []interface{}{x0, y1}, []interface{}{x0, y0},
+	}
+}
+
+func point(x, y float64) map[string]interface{} {
+	return map[string]interface{}{"type": "Point", "coordinates": []interface{}{x, y}}
+}
+
+var (
+	// A square from 0 to 10 with a hole from 4 to 6
+	squareWithHole = map[string]interface{}{
+		"type":        "Polygon",
+		"coordinates": []interface{}{square(0, 0, 10, 10), square(4, 4, 6, 6)},
+	}
+
+	evalVars = &FilterVars{
+		Now:      time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC),
+		User:     Item{"id": "u1", "team": map[string]interface{}{"id": "t1"}},
+		Role:     "r1",
+		Policies: []interface{}{"p1", "p2"},
+	}
+)
+
+// evalItem is an item as the API returns it, with its relations expanded
+func evalItem() Item {
+	return Item{
+		"id":           float64(1),
+		"title":        "Hello World",
+		"status":       "published",
+		"views":        float64(150),
+		"price":        "12.50",
+		"featured":     true,
+		"deleted_at":   nil,
+		"notes":        "",
+		"labels":       []interface{}{},
+		"owner":        "u1",
+		"team":         "t1",
+		"role":         "r1",
+		"policy":       "p2",
+		"date_created": "2024-03-15T10:30:45.000Z",
+		"publish_on":   "2024-03-20",
+		"author":       map[string]interface{}{"id": float64(7), "name": "Ann"},
+		"editor":       nil,
+		"category":     float64(3), // Not expanded
+		"tags": []interface{}{
+			map[string]interface{}{"name": "go", "weight": float64(2)},
+			map[string]interface{}{"name": "sql", "weight": float64(1)},
+		},
+		"comments": nil,
+		"location": point(5, 5),
+		"depot":    point(2, 2),
+	}
+}
+
+type filterCase struct {
+	name   string
+	filter map[string]interface{}
+	want   bool
+}
+
+// on returns a condition on a field, nesting dotted fields
+func on(field string, op FilterOperator, operand interface{}) map[string]interface{} {
+	return FilterCondition{Field: field, Operator: op, Value: operand}.Map()
+}
+
+func runFilterCases(t *testing.T, cases []filterCase) {
+	t.Helper()
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchFilter(tt.filter, evalItem(), evalVars)
+			if err != nil {
+				t.Fatalf("MatchFilter: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MatchFilter(%v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchFilterOperators(t *testing.T) {
+	runFilterCases(t, []filterCase{
+		{"eq", on("status", FilterEqual, "published"), true},
+		{"eq number", on("views", FilterEqual, 150), true},
+		{"eq other", on("status", FilterEqual, "draft"), false},
+		{"eq null", on("deleted_at", FilterEqual, nil), true},
+		{"neq", on("status", FilterNotEqual, "draft"), true},
+		{"neq same", on("status", FilterNotEqual, "published"), false},
+		{"lt", on("views", FilterLessThan, 200), true},
+		{"lt equal", on("views", FilterLessThan, 150), false},
+		{"lte", on("views", FilterLessThanEqual, 150), true},
+		{"gt", on("views", FilterGreaterThan, 100), true},
+		{"gt decimal string", on("price", FilterGreaterThan, 12.4), true},
+		{"gte", on("views", FilterGreaterThanEq, 151), false},
+		{"gt date", on("publish_on", FilterGreaterThan, "2024-03-19"), true},
+		{"in", on("status", FilterIn, []interface{}{"draft", "published"}), true},
+		{"in comma string", on("status", FilterIn, "draft,published"), true},
+		{"in missing", on("status", FilterIn, []interface{}{"draft"}), false},
+		{"nin", on("status", FilterNotIn, []interface{}{"draft"}), true},
+		{"between", on("views", FilterBetween, []interface{}{100, 200}), true},
+		{"between bounds inclusive", on("views", FilterBetween, []interface{}{150, 150}), true},
+		{"between outside", on("views", FilterBetween, []interface{}{1, 2}), false},
+		{"nbetween", on("views", FilterNotBetween, []interface{}{1, 2}), true},
+		{"contains", on("title", FilterContains, "World"), true},
+		{"contains case", on("title", FilterContains, "world"), false},
+		{"ncontains", on("title", FilterNotContains, "world"), true},
+		{"icontains", on("title", FilterIContains, "world"), true},
+		{"nicontains", on("title", FilterNotIContains, "world"), false},
+		{"starts_with", on("title", FilterStartsWith, "Hello"), true},
+		{"nstarts_with", on("title", FilterNotStartsWith, "Hello"), false},
+		{"istarts_with", on("title", FilterIStartsWith, "hello"), true},
+		{"nistarts_with", on("title", FilterNotIStartsWith, "hello"), false},
+		{"ends_with", on("title", FilterEndsWith, "World"), true},
+		{"nends_with", on("title", FilterNotEndsWith, "World"), false},
+		{"iends_with", on("title", FilterIEndsWith, "WORLD"), true},
+		{"niends_with", on("title", FilterNotIEndsWith, "WORLD"), false},
+		{"regex", on("title", FilterRegex, "/^Hel+o/"), true},
+		{"regex no match", on("title", FilterRegex, "^World"), false},
+		{"null", on("deleted_at", FilterNull, true), true},
+		{"null set", on("status", FilterNull, true), false},
+		{"nnull", on("status", FilterNotNull, true), true},
+		{"empty string", on("notes", FilterEmpty, true), true},
+		{"empty list", on("labels", FilterEmpty, true), true},
+		{"empty null", on("deleted_at", FilterEmpty, true), true},
+		{"empty set", on("title", FilterEmpty, true), false},
+		{"nempty", on("title", FilterNotEmpty, true), true},
+		{"and", map[string]interface{}{"_and": []interface{}{on("status", FilterEqual, "published"), on("views", FilterGreaterThan, 1000)}}, false},
+		{"or", map[string]interface{}{"_or": []interface{}{on("status", FilterEqual, "draft"), on("views", FilterGreaterThan, 100)}}, true},
+	})
+}
+
+func TestMatchFilterNulls(t *testing.T) {
+	runFilterCases(t, []filterCase{
+		// Like SQL, comparisons with null are false either way
+		{"gt null", on("deleted_at", FilterGreaterThan, "2024-01-01"), false},
+		{"lte null", on("deleted_at", FilterLessThanEqual, "2024-01-01"), false},
+		{"neq null value", on("deleted_at", FilterNotEqual, "x"), false},
+		{"in null", on("deleted_at", FilterIn, []interface{}{"x"}), false},
+		{"nin null", on("deleted_at", FilterNotIn, []interface{}{"x"}), false},
+		{"ncontains null", on("deleted_at", FilterNotContains, "x"), false},
+		{"missing field", on("missing", FilterEqual, "x"), false},
+
+		// _null: false and _nnull: false invert the condition
+		{"null false on null", on("deleted_at", FilterNull, false), false},
+		{"null false on value", on("status", FilterNull, false), true},
+		{"nnull false on null", on("deleted_at", FilterNotNull, false), true},
+		{"nnull false on value", on("status", FilterNotNull, false), false},
+		{"empty false", on("notes", FilterEmpty, false), false},
+		{"nempty false", on("notes", FilterNotEmpty, false), true},
+	})
+}
+
+func TestMatchFilterRelations(t *testing.T) {
+	runFilterCases(t, []filterCase{
+		{"many-to-one field", on("author.name", FilterEqual, "Ann"), true},
+		{"many-to-one other", on("author.name", FilterEqual, "Bob"), false},
+
+		// A null many-to-one relation joins a row of nulls
+		{"null many-to-one eq", on("editor.name", FilterEqual, "Ann"), false},
+		{"null many-to-one neq", on("editor.name", FilterNotEqual, "Ann"), false},
+		{"null many-to-one null", on("editor.name", FilterNull, true), true},
+		{"null many-to-one nnull", on("editor.name", FilterNotNull, true), false},
+
+		// Conditions on one-to-many fields match when some related item does
+		{"one-to-many any", on("tags.name", FilterEqual, "sql"), true},
+		{"one-to-many none match", on("tags.name", FilterEqual, "rust"), false},
+		{"one-to-many neq some", on("tags.name", FilterNotEqual, "go"), true},
+		{"some", on("tags", FilterSome, on("weight", FilterGreaterThan, 1)), true},
+		{"some none match", on("tags", FilterSome, on("weight", FilterGreaterThan, 5)), false},
+		{"none", on("tags", FilterNone, on("name", FilterEqual, "rust")), true},
+		{"none match", on("tags", FilterNone, on("name", FilterEqual, "go")), false},
+		{"some on null relation", on("comments", FilterSome, on("id", FilterNotNull, true)), false},
+		{"none on null relation", on("comments", FilterNone, on("id", FilterNotNull, true)), true},
+		{"some on same item", map[string]interface{}{"tags": map[string]interface{}{"_some": map[string]interface{}{
+			"name":   map[string]interface{}{"_eq": "go"},
+			"weight": map[string]interface{}{"_eq": 1},
+		}}}, false},
+		{"count", on("count(tags)", FilterEqual, 2), true},
+		{"count null", on("count(comments)", FilterEqual, 0), true},
+	})
+}
+
+func TestMatchFilterVariables(t *testing.T) {
+	runFilterCases(t, []filterCase{
+		{"now", on("date_created", FilterLessThan, "$NOW"), true},
+		{"now offset", on("date_created", FilterGreaterThan, "$NOW(-1 day)"), true},
+		{"now offset hours", on("date_created", FilterGreaterThan, "$NOW(-12 hours)"), false},
+		{"now offset without space", on("date_created", FilterGreaterThan, "$NOW(-2days)"), true},
+		{"now offset positive", on("publish_on", FilterLessThan, NowOffset(1, Weeks)), true},
+		{"now offset months", on("date_created", FilterBetween, []interface{}{NowOffset(-1, Months), VarNow}), true},
+		{"current user", on("owner", FilterEqual, VarCurrentUser), true},
+		{"current user field", on("team", FilterEqual, "$CURRENT_USER.team.id"), true},
+		{"current role", on("role", FilterEqual, "$CURRENT_ROLE"), true},
+		{"current policies", on("policy", FilterIn, []interface{}{"$CURRENT_POLICIES"}), true},
+		{"current policies string", on("policy", FilterIn, "$CURRENT_POLICIES"), true},
+	})
+}
+
+func TestMatchFilterFunctionFields(t *testing.T) {
+	// 2024-03-15T10:30:45Z is a Friday in ISO week 11
+	runFilterCases(t, []filterCase{
+		{"year", on(Year("date_created"), FilterEqual, 2024), true},
+		{"month", on(Month("date_created"), FilterEqual, 3), true},
+		{"week", on(Week("date_created"), FilterEqual, 11), true},
+		{"day", on(Day("date_created"), FilterEqual, 15), true},
+		{"weekday", on(Weekday("date_created"), FilterEqual, 5), true},
+		{"hour", on(Hour("date_created"), FilterEqual, 10), true},
+		{"minute", on(Minute("date_created"), FilterEqual, 30), true},
+		{"second", on(Second("date_created"), FilterEqual, 45), true},
+		{"date only", on(Day("publish_on"), FilterEqual, 20), true},
+		{"null", on(Year("deleted_at"), FilterNull, true), true},
+		{"related", on(Year("author.date_created"), FilterNull, true), true},
+	})
+}
+
+func TestMatchFilterGeometry(t *testing.T) {
+	line := map[string]interface{}{"type": "LineString", "coordinates": []interface{}{[]interface{}{-1, 5}, []interface{}{1, 5}}}
+	far := map[string]interface{}{"type": "Polygon", "coordinates": []interface{}{square(20, 20, 30, 30)}}
+
+	runFilterCases(t, []filterCase{
+		{"point in hole", on("location", FilterIntersects, squareWithHole), false},
+		{"point in polygon", on("depot", FilterIntersects, squareWithHole), true},
+		{"nintersects hole", on("location", FilterNotIntersects, squareWithHole), true},
+		{"bbox covers hole", on("location", FilterIntersectsBBox, squareWithHole), true},
+		{"nintersects_bbox", on("location", FilterNotIntersectsBBox, far), true},
+		{"polygon far", on("depot", FilterIntersects, far), false},
+		{"nintersects line", on("depot", FilterNotIntersects, line), true},
+		{"GeoJSON string", on("depot", FilterIntersects, `{"type":"Polygon","coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}`), true},
+	})
+
+	// A polygon and a line crossing its edge intersect
+	item := Item{"area": squareWithHole}
+	ok, err := MatchFilter(on("area", FilterIntersects, line), item, nil)
+	if err != nil || !ok {
+		t.Errorf("line crossing the polygon edge: %v, %v, want true", ok, err)
+	}
+	inHole := map[string]interface{}{"type": "LineString", "coordinates": []interface{}{[]interface{}{4.5, 5}, []interface{}{5.5, 5}}}
+	ok, err = MatchFilter(on("area", FilterIntersects, inHole), item, nil)
+	if err != nil || ok {
+		t.Errorf("line inside the hole: %v, %v, want false", ok, err)
+	}
+}
+
+func TestMatchFilterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter map[string]interface{}
+		want   string
+	}{
+		{"unexpanded many-to-one", on("category.name", FilterEqual, "x"), `relation "category" is not expanded`},
+		{"unexpanded some", on("category", FilterSome, on("name", FilterEqual, "x")), `relation "category" is not expanded`},
+		{"unexpanded count", on("count(category)", FilterEqual, 1), "not expanded"},
+		{"compare types", on("title", FilterGreaterThan, true), "cannot compare"},
+		{"bad offset", on("date_created", FilterGreaterThan, "$NOW(-1 fortnight)"), "invalid $NOW offset"},
+		{"between bounds", on("views", FilterBetween, []interface{}{1}), "expected two bounds"},
+		{"logical operand", map[string]interface{}{"_and": "x"}, "must be a list"},
+		{"operators", map[string]interface{}{"status": "published"}, "expected operators"},
+		{"function on text", on(Year("title"), FilterEqual, 2024), "is not a date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MatchFilter(tt.filter, evalItem(), evalVars)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("MatchFilter error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterItems(t *testing.T) {
+	items := []Item{
+		{"id": 1, "status": "published"},
+		{"id": 2, "status": "draft"},
+		{"id": 3, "status": nil},
+	}
+
+	matched, err := FilterItems(items, on("status", FilterNotEqual, "draft"), nil)
+	if err != nil {
+		t.Fatalf("FilterItems: %v", err)
+	}
+	if len(matched) != 1 || matched[0]["id"] != 1 {
+		t.Errorf("FilterItems = %v, want item 1", matched)
+	}
+
+	_, err = FilterItems(items, on("status.name", FilterEqual, "x"), nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "item 0:") {
+		t.Errorf("FilterItems error = %v, want one naming item 0", err)
+	}
+}
@@ -0,0 +1,629 @@
+package directus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseFilter parses a filter expression into the form used by
+// QueryParams.Filter, for example
+//
+//	status = "published" and (views > 100 or featured) and date_created > $NOW(-7 days)
+//
+// Conditions are a field, an operator and a value. Fields of related items
+// are separated by dots and may be function fields like year(date_created).
+// The operators are =, !=, <, <=, >, >=, "between a and b", "nbetween a and
+// b", "is null", "is not null", "is empty", "is not empty", "some (...)" and
+// "none (...)" on related items, and every other Directus operator by its
+// name without the underscore, like "in (1, 2)", "icontains "go"" or
+// "intersects {...}". A field alone matches true. Values are JSON strings,
+// numbers, true, false, null, dynamic variables like $CURRENT_USER or
+// $CURRENT_USER.role.name, lists in parentheses and JSON objects. Conditions
+// combine with "and", which binds tighter than "or", and parentheses.
+func ParseFilter(expression string) (map[string]interface{}, error) {
+	p := &filterParser{input: expression}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, p.errorf(p.pos, "unexpected %q", p.rest())
+	}
+	return filter, nil
+}
+
+// FilterSyntaxError reports an invalid filter expression
+type FilterSyntaxError struct {
+	Pos int // Column of the error, counting bytes from 1
+	Msg string
+}
+
+// Error implements the error interface
+func (e *FilterSyntaxError) Error() string {
+	return fmt.Sprintf("filter: %s at column %d", e.Msg, e.Pos)
+}
+
+// filterParser is a recursive descent parser of filter expressions
+type filterParser struct {
+	input string
+	pos   int
+}
+
+// errorf returns a *FilterSyntaxError at byte offset pos
+func (p *filterParser) errorf(pos int, format string, args ...interface{}) error {
+	return &FilterSyntaxError{Pos: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// rest returns a short excerpt of the input at the current position
+func (p *filterParser) rest() string {
+	rest := p.input[p.pos:]
+	if len(rest) > 20 {
+		rest = rest[:20] + "..."
+	}
+	return rest
+}
+
+// skipSpace advances past whitespace
+func (p *filterParser) skipSpace() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space byte, or zero at the end of the input
+func (p *filterParser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// word returns the identifier at the current position without consuming it
+func (p *filterParser) word() string {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.input) && isIdentByte(p.input[end]) {
+		end++
+	}
+	return p.input[p.pos:end]
+}
+
+// keyword consumes the keyword kw, ignoring case, if it is next
+func (p *filterParser) keyword(kw string) bool {
+	if !strings.EqualFold(p.word(), kw) {
+		return false
+	}
+	p.pos += len(kw)
+	return true
+}
+
+// expect consumes the byte c or fails
+func (p *filterParser) expect(c byte) error {
+	if p.peek() != c {
+		if p.pos >= len(p.input) {
+			return p.errorf(p.pos, "expected %q, got end of input", c)
+		}
+		return p.errorf(p.pos, "expected %q, got %q", c, p.rest())
+	}
+	p.pos++
+	return nil
+}
+
+// parseOr parses conditions separated by "or"
+func (p *filterParser) parseOr() (map[string]interface{}, error) {
+	return p.parseLogical("or", LogicalOr, p.parseAnd)
+}
+
+// parseAnd parses conditions separated by "and"
+func (p *filterParser) parseAnd() (map[string]interface{}, error) {
+	return p.parseLogical("and", LogicalAnd, p.parsePrimary)
+}
+
+// parseLogical parses operands separated by kw
+func (p *filterParser) parseLogical(kw string, op LogicalOperator, operand func() (map[string]interface{}, error)) (map[string]interface{}, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []interface{}{first}
+	for p.keyword(kw) {
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, next)
+	}
+
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return map[string]interface{}{string(op): filters}, nil
+}
+
+// parsePrimary parses a parenthesized expression or a condition
+func (p *filterParser) parsePrimary() (map[string]interface{}, error) {
+	if p.peek() != '(' {
+		return p.parseCondition()
+	}
+
+	p.pos++
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseCondition parses a field followed by an operator and a value
+func (p *filterParser) parseCondition() (map[string]interface{}, error) {
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+
+	condition := FilterCondition{Field: field}
+	switch {
+	case p.atConditionEnd():
+		condition.Operator, condition.Value = FilterEqual, true
+		return condition.Map(), nil
+	case strings.HasPrefix(p.input[p.pos:], "!="):
+		condition.Operator, p.pos = FilterNotEqual, p.pos+2
+	case strings.HasPrefix(p.input[p.pos:], "<="):
+		condition.Operator, p.pos = FilterLessThanEqual, p.pos+2
+	case strings.HasPrefix(p.input[p.pos:], ">="):
+		condition.Operator, p.pos = FilterGreaterThanEq, p.pos+2
+	case strings.HasPrefix(p.input[p.pos:], "="):
+		condition.Operator, p.pos = FilterEqual, p.pos+1
+	case strings.HasPrefix(p.input[p.pos:], "<"):
+		condition.Operator, p.pos = FilterLessThan, p.pos+1
+	case strings.HasPrefix(p.input[p.pos:], ">"):
+		condition.Operator, p.pos = FilterGreaterThan, p.pos+1
+	default:
+		return p.parseKeywordCondition(condition)
+	}
+
+	condition.Value, err = p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return condition.Map(), nil
+}
+
+// parseKeywordCondition parses a condition with a named operator
+func (p *filterParser) parseKeywordCondition(condition FilterCondition) (map[string]interface{}, error) {
+	start := p.pos
+	name := strings.ToLower(p.word())
+	if name == "" {
+		return nil, p.errorf(p.pos, "expected operator, got %q", p.rest())
+	}
+	p.pos += len(name)
+
+	var err error
+	switch name {
+	case "is":
+		negate := p.keyword("not")
+		switch {
+		case p.keyword("null"):
+			condition.Operator = FilterNull
+		case p.keyword("empty"):
+			condition.Operator = FilterEmpty
+		default:
+			return nil, p.errorf(p.pos, "expected null or empty, got %q", p.rest())
+		}
+		if negate {
+			condition.Operator = "_n" + condition.Operator[1:]
+		}
+		condition.Value = true
+
+	case "between", "nbetween":
+		condition.Operator = FilterOperator("_" + name)
+		from, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("and") {
+			return nil, p.errorf(p.pos, "expected and, got %q", p.rest())
+		}
+		to, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		condition.Value = []interface{}{from, to}
+
+	case "some", "none":
+		condition.Operator = FilterOperator("_" + name)
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		if condition.Value, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+
+	default:
+		condition.Operator = FilterOperator("_" + name)
+		if !isFilterOperator(string(condition.Operator)) {
+			return nil, p.errorf(start, "unknown operator %q", name)
+		}
+		if condition.Value, err = p.parseValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	return condition.Map(), nil
+}
+
+// atConditionEnd reports whether a field stands alone as a condition
+func (p *filterParser) atConditionEnd() bool {
+	c := p.peek()
+	word := strings.ToLower(p.word())
+	return c == 0 || c == ')' || word == "and" || word == "or"
+}
+
+// parseField parses a dotted field path whose segments may be function
+// fields like year(date_created)
+func (p *filterParser) parseField() (string, error) {
+	p.skipSpace()
+	start := p.pos
+	for {
+		segment := p.word()
+		if segment == "" {
+			if p.pos >= len(p.input) {
+				return "", p.errorf(p.pos, "expected field, got end of input")
+			}
+			return "", p.errorf(p.pos, "expected field, got %q", p.rest())
+		}
+		p.pos += len(segment)
+
+		if p.pos < len(p.input) && p.input[p.pos] == '(' {
+			end := strings.IndexByte(p.input[p.pos:], ')')
+			if end < 0 {
+				return "", p.errorf(p.pos, "unclosed function call")
+			}
+			p.pos += end + 1
+		}
+
+		if p.pos >= len(p.input) || p.input[p.pos] != '.' {
+			return p.input[start:p.pos], nil
+		}
+		p.pos++
+	}
+}
+
+// parseValue parses an operand value
+func (p *filterParser) parseValue() (interface{}, error) {
+	c := p.peek()
+	start := p.pos
+	switch {
+	case c == 0:
+		return nil, p.errorf(p.pos, "expected value, got end of input")
+
+	case c == '"' || c == '{' || c == '[':
+		decoder := json.NewDecoder(strings.NewReader(p.input[p.pos:]))
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, p.errorf(start, "invalid value: %v", err)
+		}
+		p.pos += int(decoder.InputOffset())
+		return value, nil
+
+	case c == '(':
+		p.pos++
+		values := []interface{}{}
+		if p.peek() == ')' {
+			p.pos++
+			return values, nil
+		}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if p.peek() == ',' {
+				p.pos++
+				continue
+			}
+			if err := p.expect(')'); err != nil {
+				return nil, err
+			}
+			return values, nil
+		}
+
+	case c == '$':
+		p.pos++
+		name := p.word()
+		if name == "" {
+			return nil, p.errorf(start, "expected variable name after $")
+		}
+		p.pos += len(name)
+		// Fields of the current user or role, like $CURRENT_USER.role.name
+		for p.pos+1 < len(p.input) && p.input[p.pos] == '.' && isIdentByte(p.input[p.pos+1]) {
+			p.pos++
+			p.pos += len(p.word())
+		}
+		if p.pos < len(p.input) && p.input[p.pos] == '(' {
+			end := strings.IndexByte(p.input[p.pos:], ')')
+			if end < 0 {
+				return nil, p.errorf(p.pos, "unclosed variable arguments")
+			}
+			p.pos += end + 1
+		}
+		return p.input[start:p.pos], nil
+
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		end := p.pos + 1
+		for end < len(p.input) && strings.IndexByte("0123456789.eE+-", p.input[end]) >= 0 {
+			end++
+		}
+		text := p.input[p.pos:end]
+		p.pos = end
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, p.errorf(start, "invalid number %q", text)
+		}
+		return f, nil
+	}
+
+	word := p.word()
+	switch strings.ToLower(word) {
+	case "":
+		return nil, p.errorf(p.pos, "expected value, got %q", p.rest())
+	case "true":
+		p.pos += len(word)
+		return true, nil
+	case "false":
+		p.pos += len(word)
+		return false, nil
+	case "null":
+		p.pos += len(word)
+		return nil, nil
+	}
+	return nil, p.errorf(p.pos, "expected value, got %q; quote strings", word)
+}
+
+// isIdentByte reports whether c may be part of an identifier
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// FormatFilter formats a filter in the expression syntax of ParseFilter.
+// Parsing the result gives an equivalent filter; filters returned by
+// ParseFilter come back unchanged.
+func FormatFilter(filter map[string]interface{}) (string, error) {
+	text, _, err := formatFilter(filter)
+	return text, err
+}
+
+// formatFilter formats a filter and reports how many conditions or groups
+// are joined at its top level
+func formatFilter(filter map[string]interface{}) (string, int, error) {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		switch key {
+		case string(LogicalAnd), string(LogicalOr):
+			text, n, err := formatLogical(LogicalOperator(key), filter[key])
+			if err != nil {
+				return "", 0, err
+			}
+			if n > 1 && len(keys) > 1 {
+				text = "(" + text + ")"
+			}
+			parts = append(parts, text)
+		default:
+			conditions, err := formatField([]string{key}, filter[key])
+			if err != nil {
+				return "", 0, err
+			}
+			parts = append(parts, conditions...)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", 0, fmt.Errorf("filter: empty filter")
+	}
+	return strings.Join(parts, " and "), len(parts), nil
+}
+
+// formatLogical formats the operands of _and or _or
+func formatLogical(op LogicalOperator, value interface{}) (string, int, error) {
+	filters, ok := value.([]interface{})
+	if !ok {
+		if maps, isMaps := value.([]map[string]interface{}); isMaps {
+			for _, m := range maps {
+				filters = append(filters, m)
+			}
+		} else {
+			return "", 0, fmt.Errorf("filter: %s must be a list, got %T", op, value)
+		}
+	}
+
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		text, n, err := formatOperand(f)
+		if err != nil {
+			return "", 0, err
+		}
+		if n > 1 {
+			text = "(" + text + ")"
+		}
+		parts[i] = text
+	}
+
+	if len(parts) == 0 {
+		return "", 0, fmt.Errorf("filter: empty %s", op)
+	}
+	return strings.Join(parts, " "+strings.TrimPrefix(string(op), "_")+" "), len(parts), nil
+}
+
+// formatOperand formats an operand of _and or _or. A logical filter alone
+// is reported as several parts so that it is parenthesized.
+func formatOperand(v interface{}) (string, int, error) {
+	filter := filterMap(v)
+	if filter == nil {
+		return "", 0, fmt.Errorf("filter: invalid operand %T", v)
+	}
+	text, n, err := formatFilter(filter)
+	if err != nil {
+		return "", 0, err
+	}
+	_, and := filter[string(LogicalAnd)]
+	_, or := filter[string(LogicalOr)]
+	if len(filter) == 1 && (and || or) {
+		n = 2
+	}
+	return text, n, nil
+}
+
+// formatField formats the conditions under a field path
+func formatField(path []string, value interface{}) ([]string, error) {
+	conditions, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("filter: expected operators under %q, got %T", strings.Join(path, "."), value)
+	}
+
+	keys := make([]string, 0, len(conditions))
+	for key := range conditions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		if !isFilterOperator(key) {
+			nested, err := formatField(append(path[:len(path):len(path)], key), conditions[key])
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, nested...)
+			continue
+		}
+
+		text, err := formatCondition(strings.Join(path, "."), FilterOperator(key), conditions[key])
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, text)
+	}
+	return parts, nil
+}
+
+// formatCondition formats a single condition
+func formatCondition(field string, op FilterOperator, value interface{}) (string, error) {
+	switch op {
+	case FilterEqual, FilterNotEqual, FilterLessThan, FilterLessThanEqual, FilterGreaterThan, FilterGreaterThanEq:
+		symbols := map[FilterOperator]string{
+			FilterEqual: "=", FilterNotEqual: "!=", FilterLessThan: "<",
+			FilterLessThanEqual: "<=", FilterGreaterThan: ">", FilterGreaterThanEq: ">=",
+		}
+		text, err := formatValue(value)
+		return fmt.Sprintf("%s %s %s", field, symbols[op], text), err
+
+	case FilterNull, FilterNotNull, FilterEmpty, FilterNotEmpty:
+		is := map[FilterOperator]string{
+			FilterNull: "is null", FilterNotNull: "is not null",
+			FilterEmpty: "is empty", FilterNotEmpty: "is not empty",
+		}
+		return fmt.Sprintf("%s %s", field, is[op]), nil
+
+	case FilterBetween, FilterNotBetween:
+		bounds, ok := value.([]interface{})
+		if !ok || len(bounds) != 2 {
+			return "", fmt.Errorf("filter: %s on %q needs two bounds", op, field)
+		}
+		from, err := formatValue(bounds[0])
+		if err != nil {
+			return "", err
+		}
+		to, err := formatValue(bounds[1])
+		return fmt.Sprintf("%s %s %s and %s", field, op[1:], from, to), err
+
+	case FilterSome, FilterNone:
+		text, _, err := formatOperand(value)
+		return fmt.Sprintf("%s %s (%s)", field, op[1:], text), err
+	}
+
+	text, err := formatValue(value)
+	return fmt.Sprintf("%s %s %s", field, op[1:], text), err
+}
+
+// formatValue formats an operand value
+func formatValue(value interface{}) (string, error) {
+	if v, ok := value.(Variable); ok {
+		value = string(v)
+	}
+	if s, ok := value.(string); ok && isVariable(s) {
+		return s, nil
+	}
+
+	if list, ok := value.([]interface{}); ok {
+		parts := make([]string, len(list))
+		for i, v := range list {
+			text, err := formatValue(v)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = text
+		}
+		return "(" + strings.Join(parts, ", ") + ")", nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", fmt.Errorf("filter: %w", err)
+	}
+
+	text := strings.TrimSpace(buf.String())
+	if strings.HasPrefix(text, "[") {
+		// Lists of other types, like []string, are written in parentheses too
+		var list []interface{}
+		if err := json.Unmarshal([]byte(text), &list); err == nil {
+			return formatValue(list)
+		}
+	}
+	return text, nil
+}
+
+// isVariable reports whether s is a dynamic variable like $NOW(-1 day) or
+// $CURRENT_USER.role.name
+func isVariable(s string) bool {
+	if len(s) < 2 || s[0] != '$' {
+		return false
+	}
+	name, args, hasArgs := strings.Cut(s[1:], "(")
+	for _, part := range strings.Split(name, ".") {
+		if part == "" {
+			return false
+		}
+		for i := 0; i < len(part); i++ {
+			if !isIdentByte(part[i]) {
+				return false
+			}
+		}
+	}
+	return !hasArgs || (strings.HasSuffix(args, ")") && !strings.Contains(args[:len(args)-1], ")"))
+}
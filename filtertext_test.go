@@ -0,0 +1,135 @@
+package directus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseFilterRoundTrip(t *testing.T) {
+	tests := []struct {
+		expression string
+		formatted  string
+	}{
+		{`status = "published"`, `status = "published"`},
+		{`views != 3`, `views != 3`},
+		{`price < 1.5 and price <= 2 and stock > -1 and stock >= 0`, `price < 1.5 and price <= 2 and stock > -1 and stock >= 0`},
+		{`featured`, `featured = true`},
+		{`a = 1 or b = 2 and c = 3`, `a = 1 or (b = 2 and c = 3)`},
+		{`(a = 1 or b = 2) and c = 3`, `(a = 1 or b = 2) and c = 3`},
+		{`views between 100 and 1000`, `views between 100 and 1000`},
+		{`views nbetween 1 and 2`, `views nbetween 1 and 2`},
+		{`deleted_at is null and author is not null`, `deleted_at is null and author is not null`},
+		{`tags is empty or notes is not empty`, `tags is empty or notes is not empty`},
+		{`id in (1, 2, 3) and status nin ("draft")`, `id in (1, 2, 3) and status nin ("draft")`},
+		{`title icontains "go" and slug starts_with "a"`, `title icontains "go" and slug starts_with "a"`},
+		{`author.name = "Ann"`, `author.name = "Ann"`},
+		{`year(date_created) = 2024`, `year(date_created) = 2024`},
+		{`tags some (name = "go" and weight > 1)`, `tags some (name = "go" and weight > 1)`},
+		{`tags none (name = "spam")`, `tags none (name = "spam")`},
+		{`date_created > $NOW(-7 days)`, `date_created > $NOW(-7 days)`},
+		{`owner = $CURRENT_USER`, `owner = $CURRENT_USER`},
+		{`team = $CURRENT_USER.team.id`, `team = $CURRENT_USER.team.id`},
+		{`role in ($CURRENT_ROLE, "admin")`, `role in ($CURRENT_ROLE, "admin")`},
+		{`meta = {"a":[1,2]}`, `meta = {"a":[1,2]}`},
+		{`title = "say \"hi\""`, `title = "say \"hi\""`},
+		{`flag = false and other = null`, `flag = false and other = null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			parsed, err := ParseFilter(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseFilter: %v", err)
+			}
+			formatted, err := FormatFilter(parsed)
+			if err != nil {
+				t.Fatalf("FormatFilter: %v", err)
+			}
+			if formatted != tt.formatted {
+				t.Errorf("FormatFilter = %s, want %s", formatted, tt.formatted)
+			}
+			reparsed, err := ParseFilter(formatted)
+			if err != nil {
+				t.Fatalf("ParseFilter(FormatFilter): %v", err)
+			}
+			if !reflect.DeepEqual(parsed, reparsed) {
+				t.Errorf("round trip changed the filter:\n%v\n%v", parsed, reparsed)
+			}
+		})
+	}
+}
+
+func TestParseFilterCompiles(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       map[string]interface{}
+	}{
+		{`featured`, map[string]interface{}{"featured": map[string]interface{}{"_eq": true}}},
+		{`author.name = "Ann"`, map[string]interface{}{"author": map[string]interface{}{"name": map[string]interface{}{"_eq": "Ann"}}}},
+		{`views between 1 and 2.5`, map[string]interface{}{"views": map[string]interface{}{"_between": []interface{}{int64(1), 2.5}}}},
+		{`user = $CURRENT_USER.role.name`, map[string]interface{}{"user": map[string]interface{}{"_eq": "$CURRENT_USER.role.name"}}},
+		{`a = 1 or b = 2`, map[string]interface{}{"_or": []interface{}{
+			map[string]interface{}{"a": map[string]interface{}{"_eq": int64(1)}},
+			map[string]interface{}{"b": map[string]interface{}{"_eq": int64(2)}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFilter(tt.expression)
+		if err != nil {
+			t.Errorf("ParseFilter(%s): %v", tt.expression, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseFilter(%s) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		column     int
+	}{
+		{``, 1},
+		{`status = `, 10},
+		{`status = published`, 10},
+		{`status ~ "x"`, 8},
+		{`(a = 1`, 7},
+		{`a = 1)`, 6},
+		{`a = 1 and`, 10},
+		{`views between 1 or 2`, 17},
+		{`x = $`, 5},
+		{`x = $NOW(-1 day`, 9},
+		{`x = "unterminated`, 5},
+		{`id in (1, 2`, 12},
+		{`a = 1 b = 2`, 7},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFilter(tt.expression)
+		var syntaxErr *FilterSyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("ParseFilter(%q) error = %v, want a *FilterSyntaxError", tt.expression, err)
+			continue
+		}
+		if syntaxErr.Pos != tt.column {
+			t.Errorf("ParseFilter(%q) error at column %d, want %d: %v", tt.expression, syntaxErr.Pos, tt.column, err)
+		}
+	}
+}
+
+func TestFormatFilterErrors(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"status": "published"},
+		{"_and": "status"},
+		{"_or": []interface{}{"status"}},
+	}
+
+	for _, filter := range tests {
+		if text, err := FormatFilter(filter); err == nil {
+			t.Errorf("FormatFilter(%v) = %s, want an error", filter, text)
+		}
+	}
+}
@@ -16,46 +16,46 @@ func NewFlowService(client *Client) *FlowService {
 }
 
 // List retrieves all flows
-func (s *FlowService) List(ctx context.Context) ([]Flow, error) {
+func (s *FlowService) List(ctx context.Context, params ...*QueryParams) ([]Flow, error) {
 	var resp struct {
 		Data []Flow `json:"data"`
 	}
 	path := "/flows"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "flows", Name: "list"}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to list flows: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return resp.Data, nil
 }
 
 // Get retrieves a flow by ID
-func (s *FlowService) Get(ctx context.Context, id string) (*Flow, error) {
+func (s *FlowService) Get(ctx context.Context, id string, params ...*QueryParams) (*Flow, error) {
 	var resp struct {
 		Data Flow `json:"data"`
 	}
 	path := fmt.Sprintf("/flows/%s", id)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "flows", Name: "get", ID: id}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get flow: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -68,8 +68,7 @@ func (s *FlowService) Create(ctx context.Context, flow *Flow) (*Flow, error) {
 	}
 	path := "/flows"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "flows", Name: "create"}).
 		SetBody(flow).
 		SetResult(&resp).
 		Post(path)
@@ -78,32 +77,50 @@ func (s *FlowService) Create(ctx context.Context, flow *Flow) (*Flow, error) {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to create flow: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
-// Update updates an existing flow
+// Update updates an existing flow. Fields left at their zero value are not
+// sent; use Patch to clear them.
 func (s *FlowService) Update(ctx context.Context, id string, flow *Flow) (*Flow, error) {
+	body, err := Diff(nil, flow)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, body)
+}
+
+// Patch updates the fields of a flow that differ between original and
+// modified, see Diff
+func (s *FlowService) Patch(ctx context.Context, id string, original, modified *Flow) (*Flow, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, patch)
+}
+
+// update patches a flow with body
+func (s *FlowService) update(ctx context.Context, id string, body Item) (*Flow, error) {
 	var resp struct {
 		Data Flow `json:"data"`
 	}
-	path := fmt.Sprintf("/flows/%s", id)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(flow).
+	response, err := s.client.request(ctx, Operation{Service: "flows", Name: "update", ID: id}).
+		SetBody(body).
 		SetResult(&resp).
-		Patch(path)
+		Patch(fmt.Sprintf("/flows/%s", id))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update flow: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -113,16 +130,15 @@ func (s *FlowService) Update(ctx context.Context, id string, flow *Flow) (*Flow,
 func (s *FlowService) Delete(ctx context.Context, id string) error {
 	path := fmt.Sprintf("/flows/%s", id)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "flows", Name: "delete", ID: id}).
 		Delete(path)
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != 204 {
-		return fmt.Errorf("failed to delete flow: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
 	}
 
 	return nil
@@ -132,8 +148,7 @@ func (s *FlowService) Delete(ctx context.Context, id string) error {
 func (s *FlowService) Trigger(ctx context.Context, id string, payload map[string]interface{}) error {
 	path := fmt.Sprintf("/flows/%s/trigger", id)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "flows", Name: "trigger", ID: id}).
 		SetBody(payload).
 		Post(path)
 
@@ -141,8 +156,8 @@ func (s *FlowService) Trigger(ctx context.Context, id string, payload map[string
 		return err
 	}
 
-	if response.StatusCode() != 200 {
-		return fmt.Errorf("failed to trigger flow: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
 	}
 
 	return nil
@@ -0,0 +1,344 @@
+package directus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors wrapped by *ItemPathError
+var (
+	ErrPathNotFound = errors.New("directus: path not found")
+	ErrNullValue    = errors.New("directus: null value")
+	ErrWrongType    = errors.New("directus: wrong type")
+)
+
+// ItemPathError reports a value of an Item that could not be read
+type ItemPathError struct {
+	Path string // Path that was read
+	Err  error  // ErrPathNotFound, ErrNullValue or ErrWrongType, with details
+}
+
+// Error implements the error interface
+func (e *ItemPathError) Error() string {
+	return fmt.Sprintf("item path %q: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ItemPathError) Unwrap() error {
+	return e.Err
+}
+
+// Get returns the value at path. Fields of related items are separated by
+// dots and elements of lists are indexed by number, like "author.avatar.id"
+// or "comments.0.body". Relations must be expanded through Fields to be
+// traversed.
+func (i Item) Get(path string) (interface{}, error) {
+	var current interface{} = map[string]interface{}(i)
+	parts := strings.Split(path, ".")
+	for n, part := range parts {
+		traversed := strings.Join(parts[:n], ".")
+		switch value := current.(type) {
+		case nil:
+			return nil, &ItemPathError{Path: path, Err: fmt.Errorf("%w: %s", ErrNullValue, traversed)}
+		case map[string]interface{}:
+			next, ok := value[part]
+			if !ok {
+				return nil, &ItemPathError{Path: path, Err: fmt.Errorf("%w: no field %s", ErrPathNotFound, strings.Join(parts[:n+1], "."))}
+			}
+			current = next
+		case Item:
+			next, ok := value[part]
+			if !ok {
+				return nil, &ItemPathError{Path: path, Err: fmt.Errorf("%w: no field %s", ErrPathNotFound, strings.Join(parts[:n+1], "."))}
+			}
+			current = next
+		default:
+			list, ok := toList(value)
+			if !ok {
+				return nil, &ItemPathError{Path: path, Err: fmt.Errorf("%w: %s is %T, not an object; is the relation expanded?", ErrWrongType, traversed, value)}
+			}
+			index, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, &ItemPathError{Path: path, Err: fmt.Errorf("%w: %s is a list, index it by number", ErrWrongType, traversed)}
+			}
+			if index < 0 || index >= len(list) {
+				return nil, &ItemPathError{Path: path, Err: fmt.Errorf("%w: index %d of %s out of range, length %d", ErrPathNotFound, index, traversed, len(list))}
+			}
+			current = list[index]
+		}
+	}
+	return current, nil
+}
+
+// Has reports whether the item has a value at path, null or not
+func (i Item) Has(path string) bool {
+	_, err := i.Get(path)
+	return err == nil
+}
+
+// ID returns the primary key of the item, read from its "id" field
+func (i Item) ID() string {
+	key, _ := formatID(i["id"])
+	return key
+}
+
+// String returns the string at path
+func (i Item) String(path string) (string, error) {
+	value, err := i.value(path)
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", wrongType(path, value, "a string")
+	}
+	return s, nil
+}
+
+// Int64 returns the whole number at path. JSON numbers decoded as float64
+// are accepted when they have no fraction.
+func (i Item) Int64(path string) (int64, error) {
+	value, err := i.value(path)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := toInt64(value)
+	if !ok {
+		return 0, wrongType(path, value, "a whole number")
+	}
+	return n, nil
+}
+
+// Float64 returns the number at path
+func (i Item) Float64(path string) (float64, error) {
+	value, err := i.value(path)
+	if err != nil {
+		return 0, err
+	}
+	if !isNumber(value) {
+		return 0, wrongType(path, value, "a number")
+	}
+	f, _ := toFloat(value)
+	return f, nil
+}
+
+// Bool returns the boolean at path
+func (i Item) Bool(path string) (bool, error) {
+	value, err := i.value(path)
+	if err != nil {
+		return false, err
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, wrongType(path, value, "a boolean")
+	}
+	return b, nil
+}
+
+// Time returns the date, time or timestamp at path. Values without a time
+// zone, of date and dateTime fields, are read as UTC.
+func (i Item) Time(path string) (time.Time, error) {
+	value, err := i.value(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, ok := parseTime(value)
+	if !ok {
+		return time.Time{}, wrongType(path, value, "a date")
+	}
+	return t, nil
+}
+
+// Item returns the related item at path
+func (i Item) Item(path string) (Item, error) {
+	value, err := i.value(path)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := toItem(value)
+	if !ok {
+		return nil, wrongType(path, value, "an object; is the relation expanded?")
+	}
+	return item, nil
+}
+
+// Items returns the related items at path. A null relation has no items.
+func (i Item) Items(path string) ([]Item, error) {
+	value, err := i.Get(path)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	list, ok := toList(value)
+	if !ok {
+		return nil, wrongType(path, value, "a list")
+	}
+	items := make([]Item, len(list))
+	for n, element := range list {
+		item, ok := toItem(element)
+		if !ok {
+			return nil, wrongType(fmt.Sprintf("%s.%d", path, n), element, "an object; is the relation expanded?")
+		}
+		items[n] = item
+	}
+	return items, nil
+}
+
+// StringOr returns the value at path as a string, formatting numbers and
+// booleans, or fallback when it is missing or null
+func (i Item) StringOr(path string, fallback string) string {
+	value, err := i.value(path)
+	if err != nil {
+		return fallback
+	}
+	switch value.(type) {
+	case map[string]interface{}, Item, []interface{}:
+		return fallback
+	}
+	return toString(value)
+}
+
+// Int64Or returns the value at path as a whole number, parsing strings, or
+// fallback when it is missing, null or not a whole number
+func (i Item) Int64Or(path string, fallback int64) int64 {
+	value, err := i.value(path)
+	if err != nil {
+		return fallback
+	}
+	if s, ok := value.(string); ok {
+		value = json.Number(strings.TrimSpace(s))
+	}
+	if n, ok := toInt64(value); ok {
+		return n
+	}
+	return fallback
+}
+
+// Float64Or returns the value at path as a number, parsing strings, or
+// fallback when it is missing, null or not a number
+func (i Item) Float64Or(path string, fallback float64) float64 {
+	value, err := i.value(path)
+	if err != nil {
+		return fallback
+	}
+	if f, ok := toFloat(value); ok {
+		return f
+	}
+	return fallback
+}
+
+// BoolOr returns the value at path as a boolean, parsing strings like
+// "true" and "1" and reading numbers as non-zero, or fallback when it is
+// missing, null or not a boolean
+func (i Item) BoolOr(path string, fallback bool) bool {
+	value, err := i.value(path)
+	if err != nil {
+		return fallback
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return b
+		}
+		return fallback
+	}
+	if f, ok := toFloat(value); ok {
+		return f != 0
+	}
+	return fallback
+}
+
+// TimeOr returns the date, time or timestamp at path, or fallback when it is
+// missing, null or not a date
+func (i Item) TimeOr(path string, fallback time.Time) time.Time {
+	t, err := i.Time(path)
+	if err != nil {
+		return fallback
+	}
+	return t
+}
+
+// value returns the value at path, which must not be null
+func (i Item) value(path string) (interface{}, error) {
+	value, err := i.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, &ItemPathError{Path: path, Err: fmt.Errorf("%w: %s", ErrNullValue, path)}
+	}
+	return value, nil
+}
+
+// wrongType returns the error of a value of the wrong type
+func wrongType(path string, value interface{}, expected string) error {
+	return &ItemPathError{Path: path, Err: fmt.Errorf("%w: %s is %T, not %s", ErrWrongType, path, value, expected)}
+}
+
+// toInt64 converts an integer, or a number without fraction, to an int64
+func toInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, true
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		return toInt64(f)
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case float32:
+		return toInt64(float64(v))
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// parseTime converts a time or a date string to a time, reading values
+// without a time zone as UTC
+func parseTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// toItem converts an object to an Item
+func toItem(v interface{}) (Item, bool) {
+	switch v := v.(type) {
+	case Item:
+		return v, true
+	case map[string]interface{}:
+		return Item(v), true
+	}
+	return nil, false
+}
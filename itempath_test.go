@@ -0,0 +1,252 @@
+package directus
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// pathItem is an item as the API returns it, with its relations expanded
+func pathItem() Item {
+	var item Item
+	err := json.Unmarshal([]byte(`{
+		"id": 42,
+		"title": "Hello",
+		"views": 1500,
+		"rating": 4.5,
+		"price": "12.50",
+		"featured": true,
+		"count_str": "17",
+		"flag_str": "1",
+		"deleted_at": null,
+		"date_created": "2024-03-15T10:30:45.000Z",
+		"publish_on": "2024-03-20",
+		"author": {"id": 7, "name": "Ann", "avatar": null},
+		"editor": null,
+		"category": 3,
+		"tags": [{"name": "go"}, {"name": "sql"}],
+		"comments": null,
+		"keywords": ["a", "b"]
+	}`), &item)
+	if err != nil {
+		panic(err)
+	}
+	return item
+}
+
+func TestItemGet(t *testing.T) {
+	item := pathItem()
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr error
+	}{
+		{"title", "Hello", nil},
+		{"deleted_at", nil, nil},
+		{"author.name", "Ann", nil},
+		{"author.avatar", nil, nil},
+		{"tags.1.name", "sql", nil},
+		{"keywords.0", "a", nil},
+		{"missing", nil, ErrPathNotFound},
+		{"author.missing", nil, ErrPathNotFound},
+		{"tags.2.name", nil, ErrPathNotFound},
+		{"tags.-1", nil, ErrPathNotFound},
+		{"editor.name", nil, ErrNullValue},
+		{"author.avatar.id", nil, ErrNullValue},
+		{"category.name", nil, ErrWrongType},
+		{"title.length", nil, ErrWrongType},
+		{"tags.name", nil, ErrWrongType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := item.Get(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var pathErr *ItemPathError
+				if !errors.As(err, &pathErr) || pathErr.Path != tt.path {
+					t.Errorf("Get error = %#v, want an *ItemPathError on %s", err, tt.path)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get = %v, want %v", got, tt.want)
+			}
+			if !item.Has(tt.path) {
+				t.Errorf("Has = false, want true")
+			}
+		})
+	}
+
+	if item.Has("editor.name") || item.Has("missing") {
+		t.Error("Has = true for a path without a value")
+	}
+}
+
+func TestItemStrictGetters(t *testing.T) {
+	item := pathItem()
+	check := func(t *testing.T, name string, got, want interface{}, err, wantErr error) {
+		t.Helper()
+		if !errors.Is(err, wantErr) {
+			t.Errorf("%s error = %v, want %v", name, err, wantErr)
+		} else if err == nil && !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+
+	s, err := item.String("author.name")
+	check(t, "String", s, "Ann", err, nil)
+	_, err = item.String("views")
+	check(t, "String of number", nil, nil, err, ErrWrongType)
+	_, err = item.String("deleted_at")
+	check(t, "String of null", nil, nil, err, ErrNullValue)
+	_, err = item.String("missing")
+	check(t, "String of missing", nil, nil, err, ErrPathNotFound)
+
+	n, err := item.Int64("views")
+	check(t, "Int64", n, int64(1500), err, nil)
+	_, err = item.Int64("rating")
+	check(t, "Int64 of fraction", nil, nil, err, ErrWrongType)
+	_, err = item.Int64("count_str")
+	check(t, "Int64 of string", nil, nil, err, ErrWrongType)
+	_, err = item.Int64("editor.id")
+	check(t, "Int64 through null", nil, nil, err, ErrNullValue)
+
+	f, err := item.Float64("rating")
+	check(t, "Float64", f, 4.5, err, nil)
+	_, err = item.Float64("price")
+	check(t, "Float64 of string", nil, nil, err, ErrWrongType)
+
+	b, err := item.Bool("featured")
+	check(t, "Bool", b, true, err, nil)
+	_, err = item.Bool("flag_str")
+	check(t, "Bool of string", nil, nil, err, ErrWrongType)
+
+	tm, err := item.Time("date_created")
+	check(t, "Time", tm, time.Date(2024, 3, 15, 10, 30, 45, 0, time.UTC), err, nil)
+	tm, err = item.Time("publish_on")
+	check(t, "Time of date", tm, time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC), err, nil)
+	_, err = item.Time("title")
+	check(t, "Time of text", nil, nil, err, ErrWrongType)
+
+	author, err := item.Item("author")
+	check(t, "Item", author["name"], "Ann", err, nil)
+	_, err = item.Item("category")
+	check(t, "Item of key", nil, nil, err, ErrWrongType)
+	_, err = item.Item("editor")
+	check(t, "Item of null", nil, nil, err, ErrNullValue)
+
+	if id := item.ID(); id != "42" {
+		t.Errorf("ID = %q, want 42", id)
+	}
+}
+
+func TestItemItems(t *testing.T) {
+	item := pathItem()
+
+	tags, err := item.Items("tags")
+	if err != nil || len(tags) != 2 || tags[1]["name"] != "sql" {
+		t.Errorf("Items(tags) = %v, %v, want both tags", tags, err)
+	}
+
+	// A null relation has no items
+	comments, err := item.Items("comments")
+	if err != nil || comments != nil {
+		t.Errorf("Items(comments) = %v, %v, want nil, nil", comments, err)
+	}
+
+	if _, err := item.Items("author"); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Items(author) error = %v, want ErrWrongType", err)
+	}
+	var pathErr *ItemPathError
+	if _, err := item.Items("keywords"); !errors.As(err, &pathErr) || pathErr.Path != "keywords.0" || !errors.Is(err, ErrWrongType) {
+		t.Errorf("Items(keywords) error = %v, want ErrWrongType at keywords.0", err)
+	}
+	if _, err := item.Items("missing"); !errors.Is(err, ErrPathNotFound) {
+		t.Errorf("Items(missing) error = %v, want ErrPathNotFound", err)
+	}
+}
+
+func TestItemLenientGetters(t *testing.T) {
+	item := pathItem()
+	fallback := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"StringOr", item.StringOr("title", "x"), "Hello"},
+		{"StringOr number", item.StringOr("rating", "x"), "4.5"},
+		{"StringOr bool", item.StringOr("featured", "x"), "true"},
+		{"StringOr null", item.StringOr("deleted_at", "x"), "x"},
+		{"StringOr missing", item.StringOr("missing", "x"), "x"},
+		{"StringOr object", item.StringOr("author", "x"), "x"},
+		{"StringOr list", item.StringOr("tags", "x"), "x"},
+
+		{"Int64Or", item.Int64Or("views", -1), int64(1500)},
+		{"Int64Or string", item.Int64Or("count_str", -1), int64(17)},
+		{"Int64Or decimal string", item.Int64Or("price", -1), int64(-1)},
+		{"Int64Or fraction", item.Int64Or("rating", -1), int64(-1)},
+		{"Int64Or null", item.Int64Or("editor.id", -1), int64(-1)},
+
+		{"Float64Or", item.Float64Or("rating", -1), 4.5},
+		{"Float64Or string", item.Float64Or("price", -1), 12.5},
+		{"Float64Or text", item.Float64Or("title", -1), -1.0},
+		{"Float64Or missing", item.Float64Or("missing", -1), -1.0},
+
+		{"BoolOr", item.BoolOr("featured", false), true},
+		{"BoolOr string", item.BoolOr("flag_str", false), true},
+		{"BoolOr number", item.BoolOr("views", false), true},
+		{"BoolOr text", item.BoolOr("title", true), true},
+		{"BoolOr null", item.BoolOr("deleted_at", true), true},
+
+		{"TimeOr", item.TimeOr("publish_on", fallback), time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{"TimeOr text", item.TimeOr("title", fallback), fallback},
+		{"TimeOr null", item.TimeOr("deleted_at", fallback), fallback},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestToInt64(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  int64
+		ok    bool
+	}{
+		{float64(42), 42, true},
+		{float64(-42), -42, true},
+		{2.5, 0, false},
+		{math.NaN(), 0, false},
+		{math.Inf(1), 0, false},
+		{1e300, 0, false},
+		{float64(math.MinInt64), math.MinInt64, true},
+		{float64(math.MaxInt64), 0, false}, // Rounds up to 2^63
+		{float64(1 << 62), 1 << 62, true},
+		{float32(7), 7, true},
+		{json.Number("9223372036854775807"), math.MaxInt64, true},
+		{json.Number("9223372036854775808"), 0, false},
+		{json.Number("1e3"), 1000, true},
+		{json.Number("1.5"), 0, false},
+		{json.Number("x"), 0, false},
+		{int8(-3), -3, true},
+		{uint(3), 3, true},
+		{uint64(math.MaxUint64), 0, false},
+		{"42", 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := toInt64(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("toInt64(%T %v) = %d, %v, want %d, %v", tt.value, tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
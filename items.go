@@ -3,7 +3,6 @@ package directus
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 // ItemsService handles operations on collection items
@@ -19,33 +18,7 @@ func NewItemsService(client *Client) *ItemsService {
 // Get retrieves a single item by ID
 func (s *ItemsService) Get(ctx context.Context, collection string, id string, params *QueryParams) (Item, error) {
 	var resp Response
-	path := fmt.Sprintf("/items/%s/%s", collection, id)
-
-	req := s.client.httpClient.R().
-		SetContext(ctx).
-		SetResult(&resp)
-
-	if params != nil {
-		if len(params.Fields) > 0 {
-			req.SetQueryParam("fields", joinFields(params.Fields))
-		}
-		if len(params.Aliases) > 0 {
-			req.SetQueryParam("alias", toJSONString(params.Aliases))
-		}
-		if params.Deep != nil {
-			req.SetQueryParam("deep", toJSONString(params.Deep))
-		}
-		if params.Lang != "" {
-			req.SetQueryParam("lang", params.Lang)
-		}
-	}
-
-	response, err := req.Get(path)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := parseResponse(response, &resp); err != nil {
+	if err := s.get(ctx, collection, id, params, &resp); err != nil {
 		return nil, err
 	}
 
@@ -61,90 +34,57 @@ func (s *ItemsService) Get(ctx context.Context, collection string, id string, pa
 	return Item(item), nil
 }
 
-// List retrieves multiple items from a collection
-func (s *ItemsService) List(ctx context.Context, collection string, params *QueryParams) ([]Item, *Meta, error) {
-	var resp Response
-	path := fmt.Sprintf("/items/%s", collection)
+// get retrieves a single item by ID and decodes the response into result
+func (s *ItemsService) get(ctx context.Context, collection string, id string, params *QueryParams, result interface{}) error {
+	path := fmt.Sprintf("/items/%s/%s", collection, id)
+
+	response, err := s.client.request(ctx, Operation{Service: "items", Name: "get", Collection: collection, ID: id}).
+		SetResult(result).
+		SetQueryParamsFromValues(params.Values()).
+		Get(path)
 
-	req := s.client.httpClient.R().
-		SetContext(ctx).
-		SetResult(&resp)
-
-	if params != nil {
-		if len(params.Fields) > 0 {
-			req.SetQueryParam("fields", joinFields(params.Fields))
-		}
-		if len(params.Aliases) > 0 {
-			req.SetQueryParam("alias", toJSONString(params.Aliases))
-		}
-		if params.Filter != nil {
-			req.SetQueryParam("filter", toJSONString(params.Filter))
-		}
-		if params.Search != "" {
-			req.SetQueryParam("search", params.Search)
-		}
-		if len(params.Sort) > 0 {
-			req.SetQueryParam("sort", joinFields(params.Sort))
-		}
-		if params.Limit > 0 {
-			req.SetQueryParam("limit", fmt.Sprintf("%d", params.Limit))
-		}
-		if params.Offset > 0 {
-			req.SetQueryParam("offset", fmt.Sprintf("%d", params.Offset))
-		}
-		if params.Page > 0 {
-			req.SetQueryParam("page", fmt.Sprintf("%d", params.Page))
-		}
-		if params.Deep != nil {
-			req.SetQueryParam("deep", toJSONString(params.Deep))
-		}
-		if params.Lang != "" {
-			req.SetQueryParam("lang", params.Lang)
-		}
-	}
-
-	response, err := req.Get(path)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	if err := parseResponse(response, &resp); err != nil {
-		return nil, nil, err
-	}
+	return parseResponse(response, result)
+}
 
-	data, ok := resp.Data.([]interface{})
-	if !ok {
-		return nil, nil, fmt.Errorf("invalid response format: expected array, got %T", resp.Data)
+// List retrieves multiple items from a collection
+func (s *ItemsService) List(ctx context.Context, collection string, params *QueryParams) ([]Item, *Meta, error) {
+	var resp Response
+	if err := s.list(ctx, collection, params, &resp); err != nil {
+		return nil, nil, err
 	}
 
-	items := make([]Item, len(data))
-	for i, v := range data {
-		if item, ok := v.(map[string]interface{}); ok {
-			items[i] = Item(item)
-		} else {
-			return nil, nil, fmt.Errorf("invalid item format at index %d: expected object, got %T", i, v)
-		}
+	items, err := toItems(resp.Data)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return items, resp.Meta, nil
 }
 
-// Create creates a new item in a collection
-func (s *ItemsService) Create(ctx context.Context, collection string, item Item) (Item, error) {
-	var resp Response
+// list retrieves multiple items from a collection and decodes the response into result
+func (s *ItemsService) list(ctx context.Context, collection string, params *QueryParams, result interface{}) error {
 	path := fmt.Sprintf("/items/%s", collection)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(item).
-		SetResult(&resp).
-		Post(path)
+	response, err := s.client.request(ctx, Operation{Service: "items", Name: "list", Collection: collection}).
+		SetResult(result).
+		SetQueryParamsFromValues(params.Values()).
+		Get(path)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := parseResponse(response, &resp); err != nil {
+	return parseResponse(response, result)
+}
+
+// Create creates a new item in a collection
+func (s *ItemsService) Create(ctx context.Context, collection string, item Item) (Item, error) {
+	var resp Response
+	if err := s.create(ctx, collection, item, &resp); err != nil {
 		return nil, err
 	}
 
@@ -159,19 +99,7 @@ func (s *ItemsService) Create(ctx context.Context, collection string, item Item)
 // Update updates an existing item in a collection
 func (s *ItemsService) Update(ctx context.Context, collection string, id string, item Item) (Item, error) {
 	var resp Response
-	path := fmt.Sprintf("/items/%s/%s", collection, id)
-
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(item).
-		SetResult(&resp).
-		Patch(path)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := parseResponse(response, &resp); err != nil {
+	if err := s.update(ctx, collection, id, item, &resp); err != nil {
 		return nil, err
 	}
 
@@ -183,41 +111,67 @@ func (s *ItemsService) Update(ctx context.Context, collection string, id string,
 	return Item(updatedItem), nil
 }
 
-// Delete deletes an item from a collection
-func (s *ItemsService) Delete(ctx context.Context, collection string, id string) error {
-	path := fmt.Sprintf("/items/%s/%s", collection, id)
+// Patch updates the fields of an item that differ between original and
+// modified, see Diff
+func (s *ItemsService) Patch(ctx context.Context, collection string, id string, original, modified Item) (Item, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, collection, id, patch)
+}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		Delete(path)
+// create posts body to a collection and decodes the response into result
+func (s *ItemsService) create(ctx context.Context, collection string, body interface{}, result interface{}) error {
+	path := fmt.Sprintf("/items/%s", collection)
+
+	response, err := s.client.request(ctx, Operation{Service: "items", Name: "create", Collection: collection}).
+		SetBody(body).
+		SetResult(result).
+		Post(path)
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != http.StatusNoContent {
-		return parseError(response)
+	return parseResponse(response, result)
+}
+
+// update patches an item with body and decodes the response into result
+func (s *ItemsService) update(ctx context.Context, collection string, id string, body interface{}, result interface{}) error {
+	path := fmt.Sprintf("/items/%s/%s", collection, id)
+
+	response, err := s.client.request(ctx, Operation{Service: "items", Name: "update", Collection: collection, ID: id}).
+		SetBody(body).
+		SetResult(result).
+		Patch(path)
+
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return parseResponse(response, result)
 }
 
-// DeleteMultiple deletes multiple items from a collection
-func (s *ItemsService) DeleteMultiple(ctx context.Context, collection string, ids []string) error {
-	path := fmt.Sprintf("/items/%s", collection)
+// Delete deletes an item from a collection
+func (s *ItemsService) Delete(ctx context.Context, collection string, id string) error {
+	path := fmt.Sprintf("/items/%s/%s", collection, id)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(map[string][]string{"keys": ids}).
+	response, err := s.client.request(ctx, Operation{Service: "items", Name: "delete", Collection: collection, ID: id}).
 		Delete(path)
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != http.StatusOK {
+	if !isSuccessStatus(response.StatusCode()) {
 		return parseError(response)
 	}
 
 	return nil
 }
+
+// DeleteMultiple deletes multiple items from a collection
+func (s *ItemsService) DeleteMultiple(ctx context.Context, collection string, ids []string) error {
+	return s.delete(ctx, "delete_multiple", collection, map[string][]string{"keys": ids})
+}
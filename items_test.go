@@ -0,0 +1,29 @@
+package directus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDeleteAcceptsSuccessStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusNoContent} {
+		server := newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+			w.WriteHeader(status)
+		})
+		client := server.client(t, Config{})
+		if err := client.Items.Delete(context.Background(), "articles", "1"); err != nil {
+			t.Errorf("Delete with status %d: %v", status, err)
+		}
+	}
+
+	server := newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{
+			"errors": []interface{}{map[string]interface{}{"message": "Forbidden", "extensions": map[string]interface{}{"code": "FORBIDDEN"}}},
+		})
+	})
+	if err := server.client(t, Config{}).Items.Delete(context.Background(), "articles", "1"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Delete error = %v, want ErrForbidden", err)
+	}
+}
@@ -0,0 +1,212 @@
+package directus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a client-side request budget
+type RateLimit struct {
+	RequestsPerSecond float64 // Sustained request rate. Zero means unlimited
+	Burst             int     // Requests allowed at once above the sustained rate. Defaults to 1
+	MaxConcurrent     int     // Maximum requests in flight. Zero means unlimited
+}
+
+// limiter enforces a RateLimit
+type limiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+// newLimiter creates a limiter for the given budget
+func newLimiter(limit RateLimit) *limiter {
+	l := &limiter{}
+	if limit.RequestsPerSecond > 0 {
+		l.bucket = newTokenBucket(limit.RequestsPerSecond, limit.Burst)
+	}
+	if limit.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	return l
+}
+
+// acquire waits for the rate limit and a concurrency slot. The returned
+// function releases the slot.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if l.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		// The request is not sent, so its token goes back to the bucket
+		if l.bucket != nil {
+			l.bucket.cancel()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// tokenBucket is a token bucket rate limiter
+type tokenBucket struct {
+	rate  float64 // Tokens added per second
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket creates a full bucket
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+// wait blocks until a token is available or ctx is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// limitTransport applies the client-wide, per-service and per-collection
+// budgets to every request
+type limitTransport struct {
+	global      *limiter
+	services    map[string]*limiter
+	collections map[string]*limiter
+	base        http.RoundTripper
+}
+
+// newLimitTransport returns base wrapped in the configured budgets, or base
+// itself when no budget is configured
+func newLimitTransport(global *RateLimit, services, collections map[string]RateLimit, base http.RoundTripper) http.RoundTripper {
+	if global == nil && len(services) == 0 && len(collections) == 0 {
+		return base
+	}
+
+	t := &limitTransport{
+		services:    make(map[string]*limiter, len(services)),
+		collections: make(map[string]*limiter, len(collections)),
+		base:        base,
+	}
+	if global != nil {
+		t.global = newLimiter(*global)
+	}
+	for name, limit := range services {
+		t.services[name] = newLimiter(limit)
+	}
+	for name, limit := range collections {
+		t.collections[name] = newLimiter(limit)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	op, _ := OperationFromContext(ctx)
+
+	// Acquire the narrowest budget first so a saturated collection does not
+	// hold slots of the shared budgets while it waits
+	limiters := make([]*limiter, 0, 3)
+	if l, ok := t.collections[op.Collection]; ok && op.Service == "items" {
+		limiters = append(limiters, l)
+	}
+	if l, ok := t.services[op.Service]; ok {
+		limiters = append(limiters, l)
+	}
+	if t.global != nil {
+		limiters = append(limiters, t.global)
+	}
+
+	releases := make([]func(), 0, len(limiters))
+	release := func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+	for _, l := range limiters {
+		r, err := l.acquire(ctx)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// The request stays in flight until its body has been read
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnClose calls release once when the body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
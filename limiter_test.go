@@ -0,0 +1,244 @@
+package directus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiterCanceledSlotWaitReturnsToken(t *testing.T) {
+	l := newLimiter(RateLimit{RequestsPerSecond: 0.001, Burst: 2, MaxConcurrent: 1})
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// The token is taken at once, then the wait for a slot is canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire error = %v, want context.DeadlineExceeded", err)
+	}
+	release()
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := l.acquire(ctx); err != nil {
+		t.Errorf("acquire after cancel: %v, want the canceled token back", err)
+	}
+}
+
+func TestLimiterCanceledRateWaitReturnsToken(t *testing.T) {
+	l := newLimiter(RateLimit{RequestsPerSecond: 0.001, Burst: 1})
+	if _, err := l.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire error = %v, want context.DeadlineExceeded", err)
+	}
+	if tokens := l.bucket.tokens; tokens < -0.01 || tokens > 0.01 {
+		t.Errorf("tokens = %v, want 0", tokens)
+	}
+}
+
+// inFlightServer answers after a delay and records the most requests in
+// flight at once per path, like "/users/1", per collection, like
+// "/items/articles", and for all of "/items"
+type inFlightServer struct {
+	*testServer
+
+	mu       sync.Mutex
+	current  map[string]int
+	maxSeen  map[string]int
+	arrivals map[string][]time.Time
+}
+
+func newInFlightServer(t *testing.T, delay time.Duration) *inFlightServer {
+	t.Helper()
+	s := &inFlightServer{current: map[string]int{}, maxSeen: map[string]int{}, arrivals: map[string][]time.Time{}}
+	s.testServer = newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		keys := []string{r.Path}
+		if strings.HasPrefix(r.Path, "/items/") {
+			keys = []string{"/items", strings.Join(strings.Split(r.Path, "/")[:3], "/")}
+		}
+
+		s.mu.Lock()
+		for _, key := range keys {
+			s.current[key]++
+			s.maxSeen[key] = max(s.maxSeen[key], s.current[key])
+			s.arrivals[key] = append(s.arrivals[key], time.Now())
+		}
+		s.mu.Unlock()
+
+		time.Sleep(delay)
+
+		s.mu.Lock()
+		for _, key := range keys {
+			s.current[key]--
+		}
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"id": "1"}})
+	})
+	return s
+}
+
+// maxInFlight returns the most requests in flight at once on path
+func (s *inFlightServer) maxInFlight(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxSeen[path]
+}
+
+// span returns the time between the first and last request on path
+func (s *inFlightServer) span(path string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	arrivals := s.arrivals[path]
+	if len(arrivals) < 2 {
+		return 0
+	}
+	return arrivals[len(arrivals)-1].Sub(arrivals[0])
+}
+
+// concurrently runs n copies of each call at once and returns their errors
+func concurrently(n int, calls ...func() error) []error {
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	for _, call := range calls {
+		for range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := call(); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestServiceLimits(t *testing.T) {
+	server := newInFlightServer(t, 30*time.Millisecond)
+	client := server.client(t, Config{ServiceLimits: map[string]RateLimit{"items": {MaxConcurrent: 2}}})
+	ctx := context.Background()
+
+	errs := concurrently(6,
+		func() error {
+			_, err := client.Items.Get(ctx, "articles", "1", nil)
+			return err
+		},
+		func() error {
+			_, err := client.Items.Get(ctx, "comments", "1", nil)
+			return err
+		},
+		func() error {
+			_, err := client.Users.Get(ctx, "1")
+			return err
+		},
+	)
+	if len(errs) > 0 {
+		t.Fatalf("errors: %v", errs)
+	}
+
+	if items := server.maxInFlight("/items"); items != 2 {
+		t.Errorf("items in flight = %d, want 2", items)
+	}
+	if users := server.maxInFlight("/users/1"); users < 3 {
+		t.Errorf("users in flight = %d, want users unlimited", users)
+	}
+}
+
+func TestCollectionLimits(t *testing.T) {
+	server := newInFlightServer(t, 30*time.Millisecond)
+	client := server.client(t, Config{CollectionLimits: map[string]RateLimit{"articles": {MaxConcurrent: 1}}})
+	ctx := context.Background()
+
+	errs := concurrently(5,
+		func() error {
+			_, err := client.Items.Get(ctx, "articles", "1", nil)
+			return err
+		},
+		func() error {
+			_, err := client.Items.Get(ctx, "comments", "1", nil)
+			return err
+		},
+		func() error {
+			// Only item requests count against collection budgets
+			_, err := client.Collections.Get(ctx, "articles")
+			return err
+		},
+	)
+	if len(errs) > 0 {
+		t.Fatalf("errors: %v", errs)
+	}
+
+	if n := server.maxInFlight("/items/articles"); n != 1 {
+		t.Errorf("articles in flight = %d, want 1", n)
+	}
+	if n := server.maxInFlight("/items/comments"); n < 2 {
+		t.Errorf("comments in flight = %d, want comments unlimited", n)
+	}
+	if n := server.maxInFlight("/collections/articles"); n < 2 {
+		t.Errorf("collection requests in flight = %d, want them unlimited", n)
+	}
+}
+
+func TestRateLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"global", Config{RateLimit: &RateLimit{RequestsPerSecond: 50}}},
+		{"service", Config{ServiceLimits: map[string]RateLimit{"items": {RequestsPerSecond: 50}}}},
+		{"collection", Config{CollectionLimits: map[string]RateLimit{"articles": {RequestsPerSecond: 50}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newInFlightServer(t, 0)
+			client := server.client(t, tt.config)
+
+			// A burst of one and 50 per second space 6 requests by 100ms
+			errs := concurrently(6, func() error {
+				_, err := client.Items.Get(context.Background(), "articles", "1", nil)
+				return err
+			})
+			if len(errs) > 0 {
+				t.Fatalf("errors: %v", errs)
+			}
+			if span := server.span("/items/articles"); span < 80*time.Millisecond {
+				t.Errorf("6 requests took %v, want at least 100ms", span)
+			}
+		})
+	}
+}
+
+func TestLimitCanceled(t *testing.T) {
+	server := newInFlightServer(t, 0)
+	client := server.client(t, Config{RateLimit: &RateLimit{RequestsPerSecond: 0.001}})
+
+	if _, err := client.Items.Get(context.Background(), "articles", "1", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Items.Get(ctx, "articles", "1", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get error = %v, want context.DeadlineExceeded", err)
+	}
+	if n := len(server.recorded()); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,193 @@
+package directus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody is the number of body bytes included in a log record
+const maxLoggedBody = 4096
+
+// redacted replaces the value of sensitive fields in logged bodies
+const redacted = "[REDACTED]"
+
+// sensitiveFields are always redacted from logged bodies
+var sensitiveFields = []string{
+	"access_token",
+	"refresh_token",
+	"token",
+	"password",
+	"tfa_secret",
+	"otp",
+	"auth_data",
+}
+
+// LogConfig configures request logging
+type LogConfig struct {
+	Level        slog.Leveler // Level of successful requests. Defaults to slog.LevelDebug
+	ErrorLevel   slog.Leveler // Level of failed requests. Defaults to slog.LevelWarn
+	Bodies       bool         // Log request and response bodies with sensitive fields redacted
+	RedactFields []string     // Additional body fields to redact, matched case-insensitively
+}
+
+// attemptsKey carries a counter of attempts made for a request
+type attemptsKey struct{}
+
+// withAttempts returns a context counting the attempts made for a request
+func withAttempts(ctx context.Context) (context.Context, *int) {
+	attempts := new(int)
+	return context.WithValue(ctx, attemptsKey{}, attempts), attempts
+}
+
+// countAttempt increments the attempt counter of ctx, if any
+func countAttempt(ctx context.Context) {
+	if attempts, ok := ctx.Value(attemptsKey{}).(*int); ok {
+		*attempts++
+	}
+}
+
+// loggingTransport logs every request with its outcome
+type loggingTransport struct {
+	logger     *slog.Logger
+	level      slog.Level
+	errorLevel slog.Level
+	bodies     bool
+	redact     map[string]bool
+	base       http.RoundTripper
+}
+
+// newLoggingTransport returns base wrapped in request logging
+func newLoggingTransport(logger *slog.Logger, config LogConfig, base http.RoundTripper) *loggingTransport {
+	t := &loggingTransport{
+		logger:     logger,
+		level:      slog.LevelDebug,
+		errorLevel: slog.LevelWarn,
+		bodies:     config.Bodies,
+		redact:     make(map[string]bool),
+		base:       base,
+	}
+	if config.Level != nil {
+		t.level = config.Level.Level()
+	}
+	if config.ErrorLevel != nil {
+		t.errorLevel = config.ErrorLevel.Level()
+	}
+	for _, field := range sensitiveFields {
+		t.redact[field] = true
+	}
+	for _, field := range config.RedactFields {
+		t.redact[strings.ToLower(field)] = true
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, attempts := withAttempts(req.Context())
+	req = req.WithContext(ctx)
+
+	var requestBody []byte
+	if t.bodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ = io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+
+	level := t.level
+	if err != nil || resp.StatusCode >= 400 {
+		level = t.errorLevel
+	}
+	if !t.logger.Enabled(ctx, level) {
+		return resp, err
+	}
+
+	op, _ := OperationFromContext(ctx)
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.String("service", op.Service),
+		slog.String("operation", op.Name),
+		slog.Duration("duration", duration),
+		slog.Int("retries", max(*attempts-1, 0)),
+	}
+	if op.Collection != "" {
+		attrs = append(attrs, slog.String("collection", op.Collection))
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if t.bodies {
+		if requestBody != nil {
+			attrs = append(attrs, slog.String("request_body", t.redactBody(req.Header.Get("Content-Type"), requestBody)))
+		}
+		if resp != nil && resp.Body != nil {
+			responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody+1))
+			resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(responseBody), resp.Body), Closer: resp.Body}
+			attrs = append(attrs, slog.String("response_body", t.redactBody(resp.Header.Get("Content-Type"), responseBody)))
+		}
+	}
+
+	t.logger.LogAttrs(ctx, level, "directus request", attrs...)
+	return resp, err
+}
+
+// redactBody returns a loggable form of body with sensitive fields replaced
+func (t *loggingTransport) redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if !strings.Contains(contentType, "json") {
+		return fmt.Sprintf("<%s body omitted>", contentType)
+	}
+	if len(body) > maxLoggedBody {
+		// Truncated JSON cannot be parsed, so it cannot be redacted either
+		return fmt.Sprintf("<%d+ bytes omitted>", maxLoggedBody)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "<invalid JSON omitted>"
+	}
+	return toJSONString(t.redactValue(data))
+}
+
+// redactValue replaces sensitive fields anywhere in a decoded JSON value
+func (t *loggingTransport) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if t.redact[strings.ToLower(key)] {
+				v[key] = redacted
+			} else {
+				v[key] = t.redactValue(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = t.redactValue(value)
+		}
+	}
+	return v
+}
+
+// readCloser combines a Reader and a Closer
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
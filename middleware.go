@@ -0,0 +1,83 @@
+package directus
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Operation identifies the SDK call a request belongs to
+type Operation struct {
+	Service    string // Service name, the first segment of the API path such as "items" or "users"
+	Name       string // Operation name such as "list", "get", "create", "update" or "delete"
+	Collection string // Target collection, if any
+	ID         string // Target primary key, if any
+}
+
+// operationKey carries the Operation of a request in its context
+type operationKey struct{}
+
+// withOperation returns a context carrying op
+func withOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// OperationFromContext returns the Operation of the request ctx belongs to
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// Request is an API call as seen by middleware
+type Request struct {
+	Operation
+	HTTP *http.Request
+}
+
+// Handler sends a request and returns its response
+type Handler func(req *Request) (*http.Response, error)
+
+// Middleware wraps a Handler. It may modify the request, inspect or replace
+// the response, or answer without calling next.
+type Middleware func(next Handler) Handler
+
+// Use appends middleware to the client. The first middleware added is the
+// outermost; it sees every call once, before retries and authentication.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middlewareMu.Lock()
+	defer c.middlewareMu.Unlock()
+	c.middleware = append(c.middleware[:len(c.middleware):len(c.middleware)], middleware...)
+}
+
+// request starts a request for the given operation
+func (c *Client) request(ctx context.Context, op Operation) *resty.Request {
+	return c.httpClient.R().SetContext(withOperation(ctx, op))
+}
+
+// middlewareTransport runs the client's middleware around every request
+type middlewareTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.client.middlewareMu.RLock()
+	middleware := t.client.middleware
+	t.client.middlewareMu.RUnlock()
+
+	if len(middleware) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	handler := Handler(func(r *Request) (*http.Response, error) {
+		return t.base.RoundTrip(r.HTTP)
+	})
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	op, _ := OperationFromContext(req.Context())
+	return handler(&Request{Operation: op, HTTP: req.Clone(req.Context())})
+}
@@ -0,0 +1,314 @@
+package directus
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// defaultPageSize is the number of items a Pager requests at a time
+const defaultPageSize = 100
+
+// PageOptions configures how a Pager walks a query
+type PageOptions struct {
+	PageSize  int  // Items per request. Defaults to 100
+	WithCount bool // Request meta=filter_count with the first page so Total is known
+
+	// KeyField switches to keyset pagination: items are sorted by this
+	// field and each page is requested with a _gt (or _lt) filter on the
+	// last key read, so rows added or removed during the walk never shift
+	// pages. The field must be unique and sortable, like the primary key.
+	KeyField   string
+	Descending bool   // Walk KeyField in descending order
+	Cursor     string // Resume a keyset walk after the item Pager.Cursor was taken at
+}
+
+// Pager walks all items matching a query one page at a time. The query's
+// Offset is where the walk starts and a positive Limit caps the number of
+// items returned; Page is ignored. With PageOptions.KeyField, Offset and Sort
+// are ignored too.
+//
+//	pager := client.Items.Pager(ctx, "articles", params, nil)
+//	for pager.Next() {
+//		item := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	ctx       context.Context
+	fetch     func(ctx context.Context, params *QueryParams) ([]T, *Meta, error)
+	params    QueryParams
+	size      int
+	remaining int // Items left before the limit, -1 when unlimited
+	count     bool
+	total     int
+	page      []T
+	index     int
+	item      T
+	done      bool
+	err       error
+
+	keyField string
+	desc     bool
+	keyOf    func(item T) (interface{}, error)
+	last     interface{} // Key of the last item read in keyset mode
+}
+
+// cursor is the decoded form of a keyset cursor
+type cursor struct {
+	Field string      `json:"f"`
+	Desc  bool        `json:"d,omitempty"`
+	After interface{} `json:"a"`
+}
+
+// newPager returns a Pager that fetches pages with fetch
+func newPager[T any](ctx context.Context, params *QueryParams, opts *PageOptions, fetch func(context.Context, *QueryParams) ([]T, *Meta, error), keyOf func(item T, field string) (interface{}, error)) *Pager[T] {
+	p := &Pager[T]{
+		ctx:       ctx,
+		fetch:     fetch,
+		size:      defaultPageSize,
+		remaining: -1,
+		total:     -1,
+	}
+	if params != nil {
+		p.params = *params
+	}
+	if p.params.Limit > 0 {
+		p.remaining = p.params.Limit
+	}
+	p.params.Page = 0
+	if opts != nil {
+		if opts.PageSize > 0 {
+			p.size = opts.PageSize
+		}
+		p.count = opts.WithCount
+		if opts.KeyField != "" {
+			p.keyField = opts.KeyField
+			p.desc = opts.Descending
+			p.keyOf = func(item T) (interface{}, error) {
+				return keyOf(item, p.keyField)
+			}
+			p.params.Offset = 0
+			p.params.Sort = []string{p.keyField}
+			if p.desc {
+				p.params.Sort = []string{"-" + p.keyField}
+			}
+			if opts.Cursor != "" {
+				p.err = p.resume(opts.Cursor)
+			}
+		}
+	}
+	return p
+}
+
+// resume continues a keyset walk after the item an encoded cursor was taken at
+func (p *Pager[T]) resume(encoded string) error {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c cursor
+	if err := unmarshalNumber(data, &c); err != nil {
+		return fmt.Errorf("invalid cursor: %w", err)
+	}
+	if c.Field != p.keyField || c.Desc != p.desc {
+		return fmt.Errorf("cursor was taken on %s, not %s", sortString(c.Field, c.Desc), sortString(p.keyField, p.desc))
+	}
+	p.last = c.After
+	return nil
+}
+
+// sortString returns field in the sort syntax of Directus
+func sortString(field string, desc bool) string {
+	if desc {
+		return "-" + field
+	}
+	return field
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false when all items have been read, the context is done or a
+// request failed; check Err to tell them apart.
+func (p *Pager[T]) Next() bool {
+	if p.err != nil || p.remaining == 0 {
+		return false
+	}
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	for p.index >= len(p.page) {
+		if p.done || !p.fetchPage() {
+			return false
+		}
+	}
+
+	if p.keyOf != nil {
+		key, err := p.keyOf(p.page[p.index])
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.last = key
+	}
+
+	p.item = p.page[p.index]
+	p.index++
+	if p.remaining > 0 {
+		p.remaining--
+	}
+	return true
+}
+
+// fetchPage requests the page following the last one read
+func (p *Pager[T]) fetchPage() bool {
+	params := p.params
+	params.Limit = p.size
+	if p.remaining > 0 && p.remaining < p.size {
+		params.Limit = p.remaining
+	}
+	if p.count && p.total < 0 && !slices.Contains(params.Meta, "filter_count") {
+		params.Meta = append(slices.Clip(params.Meta), "filter_count")
+	}
+	if p.keyField != "" && p.last != nil {
+		operator := FilterGreaterThan
+		if p.desc {
+			operator = FilterLessThan
+		}
+		after := map[string]interface{}{p.keyField: map[string]interface{}{string(operator): p.last}}
+		if params.Filter == nil {
+			params.Filter = after
+		} else {
+			params.Filter = map[string]interface{}{"_and": []interface{}{params.Filter, after}}
+		}
+	}
+
+	items, meta, err := p.fetch(p.ctx, &params)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	if p.count && p.total < 0 && meta != nil {
+		p.total = meta.FilterCount
+		// The count is only needed once
+		p.params.Meta = slices.DeleteFunc(slices.Clone(params.Meta), func(m string) bool {
+			return m == "filter_count"
+		})
+	}
+
+	p.page, p.index = items, 0
+	p.done = len(items) < params.Limit
+	if p.keyField == "" {
+		p.params.Offset += len(items)
+		p.done = p.done || (p.total >= 0 && p.params.Offset >= p.total)
+	}
+	return true
+}
+
+// Item returns the current item
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error that stopped the pager, if any
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// Cursor returns an opaque cursor positioned after the current item, which
+// PageOptions.Cursor resumes from. It is only available with
+// PageOptions.KeyField, and is empty before the first item.
+func (p *Pager[T]) Cursor() string {
+	if p.keyField == "" || p.last == nil {
+		return ""
+	}
+	data, err := json.Marshal(cursor{Field: p.keyField, Desc: p.desc, After: p.last})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Total returns the number of items matching the query's filter. It is only
+// known with PageOptions.WithCount, once Next has been called.
+func (p *Pager[T]) Total() (int, bool) {
+	return p.total, p.total >= 0
+}
+
+// All returns an iterator over the remaining items. An error is yielded
+// once, as the last element.
+func (p *Pager[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.item, nil) {
+				return
+			}
+		}
+		if p.err != nil {
+			var zero T
+			yield(zero, p.err)
+		}
+	}
+}
+
+// Pager returns a Pager over all items of a collection matching params
+func (s *ItemsService) Pager(ctx context.Context, collection string, params *QueryParams, opts *PageOptions) *Pager[Item] {
+	return newPager(ctx, params, opts, func(ctx context.Context, params *QueryParams) ([]Item, *Meta, error) {
+		return s.List(ctx, collection, params)
+	}, itemKey)
+}
+
+// All returns an iterator over all items of a collection matching params.
+// Each range over it starts a new walk.
+//
+//	for item, err := range client.Items.All(ctx, "articles", params, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (s *ItemsService) All(ctx context.Context, collection string, params *QueryParams, opts *PageOptions) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		s.Pager(ctx, collection, params, opts).All()(yield)
+	}
+}
+
+// Pager returns a Pager over all items matching params
+func (t *TypedItems[T]) Pager(ctx context.Context, params *QueryParams, opts *PageOptions) *Pager[T] {
+	return newPager(ctx, params, opts, t.List, structKey[T])
+}
+
+// All returns an iterator over all items matching params
+func (t *TypedItems[T]) All(ctx context.Context, params *QueryParams, opts *PageOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		t.Pager(ctx, params, opts).All()(yield)
+	}
+}
+
+// itemKey returns the value of field in item
+func itemKey(item Item, field string) (interface{}, error) {
+	key := item[field]
+	if key == nil {
+		return nil, fmt.Errorf("item has no value for key field %q; include it in Fields", field)
+	}
+	return key, nil
+}
+
+// structKey returns the value item marshals for field
+func structKey[T any](item T, field string) (interface{}, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := unmarshalNumber(data, &values); err != nil {
+		return nil, fmt.Errorf("key field %q requires %T to marshal as an object", field, item)
+	}
+	return itemKey(values, field)
+}
@@ -0,0 +1,428 @@
+package directus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newItemsServer serves a collection of n articles with ids 1 to n, every
+// other one published, applying the filter, sort, offset and limit of each
+// request. Requests for which fail returns true answer 503.
+func newItemsServer(t *testing.T, n int, fail func(r *testRequest) bool) *testServer {
+	t.Helper()
+	return newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		if fail != nil && fail(r) {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+				"errors": []interface{}{map[string]interface{}{"message": "Unavailable", "extensions": map[string]interface{}{"code": "SERVICE_UNAVAILABLE"}}},
+			})
+			return
+		}
+
+		var items []Item
+		for id := 1; id <= n; id++ {
+			status := "draft"
+			if id%2 == 0 {
+				status = "published"
+			}
+			items = append(items, Item{"id": float64(id), "status": status})
+		}
+
+		if encoded := r.Query.Get("filter"); encoded != "" {
+			var filter map[string]interface{}
+			if err := json.Unmarshal([]byte(encoded), &filter); err != nil {
+				t.Errorf("filter %s: %v", encoded, err)
+			}
+			var err error
+			if items, err = FilterItems(items, filter, nil); err != nil {
+				t.Errorf("filter %s: %v", encoded, err)
+			}
+		}
+		if r.Query.Get("sort") == "-id" {
+			slices.Reverse(items)
+		}
+		matched := len(items)
+		offset, _ := strconv.Atoi(r.Query.Get("offset"))
+		items = items[min(offset, len(items)):]
+		if limit, _ := strconv.Atoi(r.Query.Get("limit")); limit > 0 && limit < len(items) {
+			items = items[:limit]
+		}
+
+		resp := map[string]interface{}{"data": append([]Item{}, items...)}
+		if slices.Contains(strings.Split(r.Query.Get("meta"), ","), "filter_count") {
+			resp["meta"] = map[string]interface{}{"filter_count": matched}
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// pageIDs returns the ids a pager yields, and its error
+func pageIDs(p *Pager[Item]) ([]int, error) {
+	var ids []int
+	for p.Next() {
+		id, err := p.Item().Int64("id")
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, int(id))
+	}
+	return ids, p.Err()
+}
+
+// queries returns the query parameters named keys of the requests, like
+// "limit=3 offset=3"
+func queries(requests []testRequest, keys ...string) []string {
+	formatted := make([]string, len(requests))
+	for i, r := range requests {
+		var parts []string
+		for _, key := range keys {
+			if value := r.Query.Get(key); value != "" {
+				parts = append(parts, key+"="+value)
+			}
+		}
+		formatted[i] = strings.Join(parts, " ")
+	}
+	return formatted
+}
+
+func TestPagerKeyset(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   *QueryParams
+		opts     *PageOptions
+		wantIDs  []int
+		wantReqs []string
+	}{
+		{
+			name:    "ascending",
+			params:  &QueryParams{Sort: []string{"-status"}, Offset: 5},
+			opts:    &PageOptions{PageSize: 3, KeyField: "id"},
+			wantIDs: []int{1, 2, 3, 4, 5, 6, 7},
+			wantReqs: []string{
+				`sort=id limit=3`,
+				`filter={"id":{"_gt":3}} sort=id limit=3`,
+				`filter={"id":{"_gt":6}} sort=id limit=3`,
+			},
+		},
+		{
+			name:    "descending",
+			opts:    &PageOptions{PageSize: 3, KeyField: "id", Descending: true},
+			wantIDs: []int{7, 6, 5, 4, 3, 2, 1},
+			wantReqs: []string{
+				`sort=-id limit=3`,
+				`filter={"id":{"_lt":5}} sort=-id limit=3`,
+				`filter={"id":{"_lt":2}} sort=-id limit=3`,
+			},
+		},
+		{
+			name:    "filter merged under _and",
+			params:  &QueryParams{Filter: NewFilterEqual("status", "published")},
+			opts:    &PageOptions{PageSize: 2, KeyField: "id"},
+			wantIDs: []int{2, 4, 6},
+			wantReqs: []string{
+				`filter={"status":{"_eq":"published"}} sort=id limit=2`,
+				`filter={"_and":[{"status":{"_eq":"published"}},{"id":{"_gt":4}}]} sort=id limit=2`,
+			},
+		},
+		{
+			name:    "limit",
+			params:  &QueryParams{Limit: 4},
+			opts:    &PageOptions{PageSize: 3, KeyField: "id"},
+			wantIDs: []int{1, 2, 3, 4},
+			wantReqs: []string{
+				`sort=id limit=3`,
+				`filter={"id":{"_gt":3}} sort=id limit=1`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newItemsServer(t, 7, nil)
+			client := server.client(t, Config{})
+
+			ids, err := pageIDs(client.Items.Pager(context.Background(), "articles", tt.params, tt.opts))
+			if err != nil {
+				t.Fatalf("Err: %v", err)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if got := queries(server.recorded(), "filter", "sort", "offset", "limit"); !slices.Equal(got, tt.wantReqs) {
+				t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantReqs, "\n"))
+			}
+		})
+	}
+}
+
+func TestPagerCursor(t *testing.T) {
+	for _, desc := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Descending=%v", desc), func(t *testing.T) {
+			server := newItemsServer(t, 7, nil)
+			client := server.client(t, Config{})
+			opts := &PageOptions{PageSize: 3, KeyField: "id", Descending: desc}
+
+			pager := client.Items.Pager(context.Background(), "articles", nil, opts)
+			if cursor := pager.Cursor(); cursor != "" {
+				t.Errorf("Cursor before Next = %q, want none", cursor)
+			}
+			var first []int
+			for len(first) < 4 && pager.Next() {
+				id, _ := pager.Item().Int64("id")
+				first = append(first, int(id))
+			}
+			cursor := pager.Cursor()
+			if cursor == "" {
+				t.Fatal("Cursor is empty")
+			}
+
+			// The resumed key is a json.Number, which must encode as a number
+			resumed := *opts
+			resumed.Cursor = cursor
+			pager = client.Items.Pager(context.Background(), "articles", nil, &resumed)
+			rest, err := pageIDs(pager)
+			if err != nil {
+				t.Fatalf("Err after resume: %v", err)
+			}
+
+			want := []int{1, 2, 3, 4, 5, 6, 7}
+			wantFilter := `{"id":{"_gt":4}}`
+			if desc {
+				slices.Reverse(want)
+				wantFilter = `{"id":{"_lt":4}}`
+			}
+			if got := append(first, rest...); !slices.Equal(got, want) {
+				t.Errorf("ids = %v then %v, want %v", first, rest, want)
+			}
+			requests := server.recorded()
+			if got := requests[2].Query.Get("filter"); got != wantFilter {
+				t.Errorf("first filter after resume = %s, want %s", got, wantFilter)
+			}
+
+			// A resumed pager hands out cursors too
+			if pager.Cursor() == "" {
+				t.Error("Cursor after resumed walk is empty")
+			}
+		})
+	}
+}
+
+func TestPagerCursorErrors(t *testing.T) {
+	server := newItemsServer(t, 7, nil)
+	client := server.client(t, Config{})
+
+	pager := client.Items.Pager(context.Background(), "articles", nil, &PageOptions{KeyField: "id"})
+	pager.Next()
+	ascending := pager.Cursor()
+
+	tests := []struct {
+		name string
+		opts *PageOptions
+		want string
+	}{
+		{"other direction", &PageOptions{KeyField: "id", Descending: true, Cursor: ascending}, "cursor was taken on id, not -id"},
+		{"other field", &PageOptions{KeyField: "slug", Cursor: ascending}, "cursor was taken on id, not slug"},
+		{"not base64", &PageOptions{KeyField: "id", Cursor: "not a cursor!"}, "invalid cursor"},
+		{"not JSON", &PageOptions{KeyField: "id", Cursor: "bm90IGpzb24"}, "invalid cursor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(server.recorded())
+			pager := client.Items.Pager(context.Background(), "articles", nil, tt.opts)
+			if pager.Next() {
+				t.Error("Next = true, want false")
+			}
+			if err := pager.Err(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Err = %v, want %q", err, tt.want)
+			}
+			if after := len(server.recorded()); after != before {
+				t.Errorf("sent %d requests, want none", after-before)
+			}
+		})
+	}
+
+	t.Run("key field not selected", func(t *testing.T) {
+		pager := client.Items.Pager(context.Background(), "articles", nil, &PageOptions{KeyField: "slug"})
+		if pager.Next() {
+			t.Error("Next = true, want false")
+		}
+		if err := pager.Err(); err == nil || !strings.Contains(err.Error(), `no value for key field "slug"`) {
+			t.Errorf("Err = %v, want a missing key field error", err)
+		}
+	})
+}
+
+func TestTypedPagerKeyset(t *testing.T) {
+	type article struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+	}
+	server := newItemsServer(t, 5, nil)
+	articles := NewTypedItems[article](server.client(t, Config{}), "articles")
+
+	var ids []int
+	for a, err := range articles.All(context.Background(), nil, &PageOptions{PageSize: 2, KeyField: "id"}) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		ids = append(ids, a.ID)
+	}
+	if want := []int{1, 2, 3, 4, 5}; !slices.Equal(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if got := server.recorded()[1].Query.Get("filter"); got != `{"id":{"_gt":2}}` {
+		t.Errorf("second filter = %s", got)
+	}
+}
+
+func TestPagerOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   *QueryParams
+		opts     *PageOptions
+		wantIDs  []int
+		wantReqs []string
+	}{
+		{
+			name:     "pages",
+			opts:     &PageOptions{PageSize: 3},
+			wantIDs:  []int{1, 2, 3, 4, 5, 6},
+			wantReqs: []string{`limit=3`, `offset=3 limit=3`, `offset=6 limit=3`},
+		},
+		{
+			name:     "limit caps the walk",
+			params:   &QueryParams{Limit: 5},
+			opts:     &PageOptions{PageSize: 2},
+			wantIDs:  []int{1, 2, 3, 4, 5},
+			wantReqs: []string{`limit=2`, `offset=2 limit=2`, `offset=4 limit=1`},
+		},
+		{
+			name:     "offset is the start",
+			params:   &QueryParams{Offset: 3, Page: 7},
+			opts:     &PageOptions{PageSize: 2},
+			wantIDs:  []int{4, 5, 6},
+			wantReqs: []string{`offset=3 limit=2`, `offset=5 limit=2`},
+		},
+		{
+			name:     "filter_count on the first page only",
+			opts:     &PageOptions{PageSize: 3, WithCount: true},
+			wantIDs:  []int{1, 2, 3, 4, 5, 6},
+			wantReqs: []string{`limit=3 meta=filter_count`, `offset=3 limit=3`},
+		},
+		{
+			name:     "filter_count with other meta",
+			params:   &QueryParams{Meta: []string{"total_count"}},
+			opts:     &PageOptions{PageSize: 4, WithCount: true},
+			wantIDs:  []int{1, 2, 3, 4, 5, 6},
+			wantReqs: []string{`limit=4 meta=total_count,filter_count`, `offset=4 limit=4 meta=total_count`},
+		},
+		{
+			name:     "default page size",
+			wantIDs:  []int{1, 2, 3, 4, 5, 6},
+			wantReqs: []string{`limit=100`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newItemsServer(t, 6, nil)
+			client := server.client(t, Config{})
+
+			ids, err := pageIDs(client.Items.Pager(context.Background(), "articles", tt.params, tt.opts))
+			if err != nil {
+				t.Fatalf("Err: %v", err)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if got := queries(server.recorded(), "offset", "limit", "page", "meta"); !slices.Equal(got, tt.wantReqs) {
+				t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantReqs, "\n"))
+			}
+		})
+	}
+}
+
+func TestPagerTotal(t *testing.T) {
+	server := newItemsServer(t, 6, nil)
+	client := server.client(t, Config{})
+
+	params := &QueryParams{Filter: NewFilterEqual("status", "published")}
+	pager := client.Items.Pager(context.Background(), "articles", params, &PageOptions{PageSize: 2, WithCount: true})
+	if _, ok := pager.Total(); ok {
+		t.Error("Total known before Next")
+	}
+	pager.Next()
+	if total, ok := pager.Total(); !ok || total != 3 {
+		t.Errorf("Total = %d, %v, want 3, true", total, ok)
+	}
+	if len(params.Meta) != 0 {
+		t.Errorf("params.Meta = %v, want the caller's params unchanged", params.Meta)
+	}
+}
+
+func TestPagerContextCanceled(t *testing.T) {
+	server := newItemsServer(t, 6, nil)
+	client := server.client(t, Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pager := client.Items.Pager(ctx, "articles", nil, &PageOptions{PageSize: 2})
+	if !pager.Next() {
+		t.Fatalf("Next = false: %v", pager.Err())
+	}
+	cancel()
+	if pager.Next() {
+		t.Error("Next after cancel = true, want false")
+	}
+	if !errors.Is(pager.Err(), context.Canceled) {
+		t.Errorf("Err = %v, want context.Canceled", pager.Err())
+	}
+	if requests := server.recorded(); len(requests) != 1 {
+		t.Errorf("sent %d requests, want 1", len(requests))
+	}
+}
+
+func TestPagerAllYieldsErrorLast(t *testing.T) {
+	server := newItemsServer(t, 6, func(r *testRequest) bool {
+		return r.Query.Get("offset") == "4"
+	})
+	client := server.client(t, Config{})
+
+	var ids []int
+	var errs []error
+	for item, err := range client.Items.All(context.Background(), "articles", nil, &PageOptions{PageSize: 2}) {
+		if err != nil {
+			errs = append(errs, err)
+			if item != nil {
+				t.Errorf("item with error = %v, want nil", item)
+			}
+			continue
+		}
+		if len(errs) > 0 {
+			t.Error("item yielded after the error")
+		}
+		id, _ := item.Int64("id")
+		ids = append(ids, int(id))
+	}
+	if want := []int{1, 2, 3, 4}; !slices.Equal(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrServiceUnavailable) {
+		t.Errorf("errors = %v, want one ErrServiceUnavailable", errs)
+	}
+
+	// Breaking out of the loop stops the walk
+	before := len(server.recorded())
+	for range client.Items.All(context.Background(), "articles", nil, &PageOptions{PageSize: 2}) {
+		break
+	}
+	if sent := len(server.recorded()) - before; sent != 1 {
+		t.Errorf("sent %d requests before break, want 1", sent)
+	}
+}
@@ -0,0 +1,78 @@
+package directus
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Values encodes the query parameters as Directus global query parameters.
+// A nil *QueryParams encodes to no parameters.
+func (qp *QueryParams) Values() url.Values {
+	values := url.Values{}
+	if qp == nil {
+		return values
+	}
+
+	if len(qp.Fields) > 0 {
+		values.Set("fields", joinFields(qp.Fields))
+	}
+	for field, alias := range qp.Aliases {
+		values.Set(fmt.Sprintf("alias[%s]", alias), field)
+	}
+	if qp.Filter != nil {
+		values.Set("filter", toJSONString(qp.Filter))
+	}
+	if qp.Search != "" {
+		values.Set("search", qp.Search)
+	}
+	if len(qp.Sort) > 0 {
+		values.Set("sort", joinFields(qp.Sort))
+	}
+	if qp.Limit != 0 {
+		values.Set("limit", strconv.Itoa(qp.Limit))
+	}
+	if qp.Offset > 0 {
+		values.Set("offset", strconv.Itoa(qp.Offset))
+	}
+	if qp.Page > 0 {
+		values.Set("page", strconv.Itoa(qp.Page))
+	}
+	for fn, fields := range qp.Aggregate {
+		if len(fields) == 0 {
+			fields = []string{"*"}
+		}
+		values.Set(fmt.Sprintf("aggregate[%s]", fn), joinFields(fields))
+	}
+	if len(qp.GroupBy) > 0 {
+		values.Set("groupBy", joinFields(qp.GroupBy))
+	}
+	if qp.Deep != nil {
+		values.Set("deep", toJSONString(qp.Deep))
+	}
+	if qp.Export != "" {
+		values.Set("export", qp.Export)
+	}
+	if qp.Version != "" {
+		values.Set("version", qp.Version)
+	}
+	if len(qp.Meta) > 0 {
+		values.Set("meta", joinFields(qp.Meta))
+	}
+	if qp.Backlink != nil {
+		values.Set("backlink", strconv.FormatBool(*qp.Backlink))
+	}
+	if qp.Lang != "" {
+		values.Set("lang", qp.Lang)
+	}
+
+	return values
+}
+
+// optionalParams returns the first of optional query parameters, if any
+func optionalParams(params []*QueryParams) *QueryParams {
+	if len(params) == 0 {
+		return nil
+	}
+	return params[0]
+}
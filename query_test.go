@@ -0,0 +1,50 @@
+package directus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestQueryParamsSent(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		data := interface{}(map[string]interface{}{})
+		if r.Path == "/services" {
+			data = []interface{}{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+	})
+	client := server.client(t, Config{})
+	ctx := context.Background()
+	params := &QueryParams{Fields: []string{"project_name", "project_url"}}
+
+	calls := map[string]func() error{
+		"/settings": func() error {
+			_, err := client.Settings.Get(ctx, params)
+			return err
+		},
+		"/system/settings": func() error {
+			_, err := client.System.GetSettings(ctx, params)
+			return err
+		},
+		"/services": func() error {
+			_, err := client.Services.List(ctx, params)
+			return err
+		},
+	}
+	for path, call := range calls {
+		if err := call(); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+
+	requests := server.recorded()
+	if len(requests) != len(calls) {
+		t.Fatalf("sent %d requests, want %d", len(requests), len(calls))
+	}
+	for _, r := range requests {
+		if got := r.Query.Get("fields"); got != "project_name,project_url" {
+			t.Errorf("%s fields = %q, want project_name,project_url", r.Path, got)
+		}
+	}
+}
@@ -16,46 +16,46 @@ func NewRelationsService(client *Client) *RelationsService {
 }
 
 // List retrieves all relations
-func (s *RelationsService) List(ctx context.Context) ([]Relation, error) {
+func (s *RelationsService) List(ctx context.Context, params ...*QueryParams) ([]Relation, error) {
 	var resp struct {
 		Data []Relation `json:"data"`
 	}
 	path := "/relations"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "relations", Name: "list"}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to list relations: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return resp.Data, nil
 }
 
 // Get retrieves a relation by name
-func (s *RelationsService) Get(ctx context.Context, name string) (*Relation, error) {
+func (s *RelationsService) Get(ctx context.Context, name string, params ...*QueryParams) (*Relation, error) {
 	var resp struct {
 		Data Relation `json:"data"`
 	}
 	path := fmt.Sprintf("/relations/%s", name)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "relations", Name: "get", ID: name}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get relation: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -68,8 +68,7 @@ func (s *RelationsService) Create(ctx context.Context, relation *Relation) (*Rel
 	}
 	path := "/relations"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "relations", Name: "create"}).
 		SetBody(relation).
 		SetResult(&resp).
 		Post(path)
@@ -78,32 +77,50 @@ func (s *RelationsService) Create(ctx context.Context, relation *Relation) (*Rel
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to create relation: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
-// Update updates an existing relation
+// Update updates an existing relation. Fields left at their zero value are not
+// sent; use Patch to clear them.
 func (s *RelationsService) Update(ctx context.Context, name string, relation *Relation) (*Relation, error) {
+	body, err := Diff(nil, relation)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, name, body)
+}
+
+// Patch updates the fields of a relation that differ between original and
+// modified, see Diff
+func (s *RelationsService) Patch(ctx context.Context, name string, original, modified *Relation) (*Relation, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, name, patch)
+}
+
+// update patches a relation with body
+func (s *RelationsService) update(ctx context.Context, name string, body Item) (*Relation, error) {
 	var resp struct {
 		Data Relation `json:"data"`
 	}
-	path := fmt.Sprintf("/relations/%s", name)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(relation).
+	response, err := s.client.request(ctx, Operation{Service: "relations", Name: "update", ID: name}).
+		SetBody(body).
 		SetResult(&resp).
-		Patch(path)
+		Patch(fmt.Sprintf("/relations/%s", name))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update relation: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -113,16 +130,15 @@ func (s *RelationsService) Update(ctx context.Context, name string, relation *Re
 func (s *RelationsService) Delete(ctx context.Context, name string) error {
 	path := fmt.Sprintf("/relations/%s", name)
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "relations", Name: "delete", ID: name}).
 		Delete(path)
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != 204 {
-		return fmt.Errorf("failed to delete relation: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
 	}
 
 	return nil
@@ -0,0 +1,192 @@
+package directus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried
+type RetryPolicy struct {
+	MaxRetries     int           // Maximum number of retries after the first attempt
+	InitialBackoff time.Duration // Wait before the first retry, doubled for each further retry. Defaults to 200ms
+	MaxBackoff     time.Duration // Upper bound for a single wait, including Retry-After. Defaults to 10s
+	Jitter         float64       // Fraction (0 to 1) of each wait that is randomized
+	RetryStatuses  []int         // Status codes to retry. Defaults to 429, 502, 503 and 504
+
+	// RetryNonIdempotent also retries POST and PATCH requests, which may
+	// apply a change twice if the server processed the first attempt
+	RetryNonIdempotent bool
+
+	// OnRetry is called before every retry
+	OnRetry func(attempt RetryAttempt)
+}
+
+// RetryAttempt describes a failed attempt that is about to be retried
+type RetryAttempt struct {
+	Attempt    int           // Number of the failed attempt, starting at 1
+	Method     string        // HTTP method of the request
+	Path       string        // Request path
+	StatusCode int           // Status code of the failed attempt, 0 on transport errors
+	Err        error         // Transport error of the failed attempt, if any
+	Wait       time.Duration // Time to wait before the next attempt
+}
+
+// DefaultRetryPolicy returns a policy that retries idempotent requests up to
+// three times on rate limiting and transient gateway errors
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryable reports whether a request with the given method may be retried
+func (p *RetryPolicy) retryable(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// shouldRetry reports whether the outcome of an attempt warrants a retry
+func (p *RetryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	statuses := p.RetryStatuses
+	if len(statuses) == 0 {
+		statuses = defaultRetryStatuses
+	}
+	for _, status := range statuses {
+		if resp.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before retry number n (starting at 1)
+func (p *RetryPolicy) backoff(n int, resp *http.Response) time.Duration {
+	initial, maxWait := p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = 200 * time.Millisecond
+	}
+	if maxWait <= 0 {
+		maxWait = 10 * time.Second
+	}
+
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, maxWait)
+		}
+	}
+
+	wait := time.Duration(float64(initial) * math.Pow(2, float64(n-1)))
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+	if p.Jitter > 0 {
+		jitter := float64(wait) * min(p.Jitter, 1)
+		wait = time.Duration(float64(wait) - jitter + rand.Float64()*2*jitter)
+	}
+	return min(wait, maxWait)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// retryTransport retries requests according to a RetryPolicy
+type retryTransport struct {
+	policy *RetryPolicy
+	base   http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if !t.policy.retryable(req.Method) || !replayable {
+		return t.base.RoundTrip(req)
+	}
+
+	attemptReq := req
+	for attempt := 1; ; attempt++ {
+		countAttempt(ctx)
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt > t.policy.MaxRetries || !t.policy.shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := t.policy.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			// The next attempt could not finish before the deadline
+			return resp, err
+		}
+
+		next := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, berr := req.GetBody()
+			if berr != nil {
+				return resp, err
+			}
+			next.Body = body
+		}
+
+		if t.policy.OnRetry != nil {
+			info := RetryAttempt{
+				Attempt: attempt,
+				Method:  req.Method,
+				Path:    req.URL.Path,
+				Err:     err,
+				Wait:    wait,
+			}
+			if resp != nil {
+				info.StatusCode = resp.StatusCode
+			}
+			t.policy.OnRetry(info)
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		attemptReq = next
+	}
+}
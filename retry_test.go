@@ -0,0 +1,241 @@
+package directus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	header := func(value string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+	}
+	policy := &RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 25 * time.Millisecond}
+	capped := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	tests := []struct {
+		name   string
+		policy *RetryPolicy
+		n      int
+		resp   *http.Response
+		want   time.Duration
+	}{
+		{"first", policy, 1, nil, 10 * time.Millisecond},
+		{"doubled", policy, 2, nil, 20 * time.Millisecond},
+		{"capped", policy, 3, nil, 25 * time.Millisecond},
+		{"overflow capped", policy, 100, nil, 25 * time.Millisecond},
+		{"defaults", &RetryPolicy{}, 1, nil, 200 * time.Millisecond},
+		{"default cap", &RetryPolicy{}, 10, nil, 10 * time.Second},
+		{"no header", policy, 1, &http.Response{Header: http.Header{}}, 10 * time.Millisecond},
+		{"retry-after seconds", capped, 1, header("3"), 3 * time.Second},
+		{"retry-after zero", capped, 3, header("0"), 0},
+		{"retry-after negative", capped, 1, header("-5"), 0},
+		{"retry-after capped", capped, 1, header("120"), 5 * time.Second},
+		{"retry-after past date", capped, 1, header(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)), 0},
+		{"retry-after invalid", capped, 2, header("soon"), 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.backoff(tt.n, tt.resp); got != tt.want {
+				t.Errorf("backoff(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("retry-after date", func(t *testing.T) {
+		date := time.Now().Add(4 * time.Second).UTC().Format(http.TimeFormat)
+		got := capped.backoff(1, header(date))
+		// HTTP dates have a resolution of one second
+		if got < 2*time.Second || got > 4*time.Second {
+			t.Errorf("backoff = %v, want about 3s", got)
+		}
+	})
+
+	t.Run("retry-after date capped", func(t *testing.T) {
+		date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		if got := capped.backoff(1, header(date)); got != 5*time.Second {
+			t.Errorf("backoff = %v, want 5s", got)
+		}
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		jittered := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: 0.5}
+		for range 100 {
+			if got := jittered.backoff(1, nil); got < 50*time.Millisecond || got > 150*time.Millisecond {
+				t.Fatalf("backoff = %v, want 50ms to 150ms", got)
+			}
+		}
+	})
+}
+
+// newStatusServer answers with the given statuses in turn, then 200, and
+// sets Retry-After on the failures when retryAfter is not empty
+func newStatusServer(t *testing.T, retryAfter string, statuses ...int) *testServer {
+	t.Helper()
+	var mu sync.Mutex
+	return newTestServer(t, func(w http.ResponseWriter, r *testRequest) {
+		mu.Lock()
+		status := http.StatusOK
+		if len(statuses) > 0 {
+			status, statuses = statuses[0], statuses[1:]
+		}
+		mu.Unlock()
+
+		if status == http.StatusOK {
+			writeJSON(w, status, map[string]interface{}{"data": map[string]interface{}{"id": 1}})
+			return
+		}
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		writeJSON(w, status, map[string]interface{}{
+			"errors": []interface{}{map[string]interface{}{"message": http.StatusText(status)}},
+		})
+	})
+}
+
+// fastRetries returns a policy that retries without waiting
+func fastRetries(retries int) *RetryPolicy {
+	return &RetryPolicy{MaxRetries: retries, InitialBackoff: time.Nanosecond, MaxBackoff: time.Nanosecond}
+}
+
+func TestRetryStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		policy   *RetryPolicy
+		wantErr  error
+		attempts int
+	}{
+		{"recovers", []int{503, 502, 504}, fastRetries(3), nil, 4},
+		{"rate limited", []int{429}, fastRetries(3), nil, 2},
+		{"gives up", []int{503, 503, 503, 503}, fastRetries(2), ErrServiceUnavailable, 3},
+		{"not retried status", []int{400}, fastRetries(3), ErrBadRequest, 1},
+		{"custom statuses", []int{500, 503}, &RetryPolicy{MaxRetries: 3, InitialBackoff: time.Nanosecond, RetryStatuses: []int{500}}, ErrServiceUnavailable, 2},
+		{"disabled", []int{503}, nil, ErrServiceUnavailable, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStatusServer(t, "", tt.statuses...)
+			client := server.client(t, Config{Retry: tt.policy})
+
+			_, err := client.Items.Get(context.Background(), "articles", "1", nil)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Get: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts := len(server.recorded()); attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryAfterHonored(t *testing.T) {
+	server := newStatusServer(t, "0", 429, 429)
+	var attempts []RetryAttempt
+	client := server.client(t, Config{Retry: &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Hour, // Retry-After takes precedence
+		MaxBackoff:     time.Hour,
+		OnRetry:        func(a RetryAttempt) { attempts = append(attempts, a) },
+	}})
+
+	if _, err := client.Items.Get(context.Background(), "articles", "1", nil); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("OnRetry called %d times, want 2", len(attempts))
+	}
+	for i, a := range attempts {
+		want := RetryAttempt{Attempt: i + 1, Method: http.MethodGet, Path: "/items/articles/1", StatusCode: 429}
+		if a != want {
+			t.Errorf("OnRetry(%+v), want %+v", a, want)
+		}
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	create := func(client *Client) error {
+		_, err := client.Items.Create(context.Background(), "articles", Item{"title": "Once"})
+		return err
+	}
+
+	t.Run("POST not retried", func(t *testing.T) {
+		server := newStatusServer(t, "", 503)
+		client := server.client(t, Config{Retry: fastRetries(3)})
+		if err := create(client); !errors.Is(err, ErrServiceUnavailable) {
+			t.Errorf("Create error = %v, want ErrServiceUnavailable", err)
+		}
+		if attempts := len(server.recorded()); attempts != 1 {
+			t.Errorf("attempts = %d, want 1", attempts)
+		}
+	})
+
+	t.Run("POST retried when enabled", func(t *testing.T) {
+		server := newStatusServer(t, "", 503)
+		policy := fastRetries(3)
+		policy.RetryNonIdempotent = true
+		client := server.client(t, Config{Retry: policy})
+		if err := create(client); err != nil {
+			t.Errorf("Create: %v", err)
+		}
+		requests := server.recorded()
+		if len(requests) != 2 {
+			t.Fatalf("attempts = %d, want 2", len(requests))
+		}
+		for i, r := range requests {
+			if r.Body != `{"title":"Once"}` {
+				t.Errorf("attempt %d body = %q, want the item", i+1, r.Body)
+			}
+		}
+	})
+}
+
+func TestRetryContextDeadline(t *testing.T) {
+	server := newStatusServer(t, "5", 503, 503)
+	var retries int
+	client := server.client(t, Config{Retry: &RetryPolicy{
+		MaxRetries: 3,
+		OnRetry:    func(RetryAttempt) { retries++ },
+	}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Items.Get(ctx, "articles", "1", nil)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Get took %v, want it to give up before waiting", elapsed)
+	}
+	// The last response is returned rather than a context error
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("Get error = %v, want ErrServiceUnavailable", err)
+	}
+	if retries != 0 || len(server.recorded()) != 1 {
+		t.Errorf("retries, attempts = %d, %d, want 0, 1", retries, len(server.recorded()))
+	}
+}
+
+func TestRetryCanceledWhileWaiting(t *testing.T) {
+	server := newStatusServer(t, "", 503, 503)
+	ctx, cancel := context.WithCancel(context.Background())
+	client := server.client(t, Config{Retry: &RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: time.Hour,
+		MaxBackoff:     time.Hour,
+		OnRetry:        func(RetryAttempt) { cancel() },
+	}})
+
+	_, err := client.Items.Get(ctx, "articles", "1", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Get error = %v, want context.Canceled", err)
+	}
+	if attempts := len(server.recorded()); attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
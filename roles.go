@@ -16,22 +16,22 @@ func NewRolesService(client *Client) *RolesService {
 }
 
 // Get retrieves a role by ID
-func (s *RolesService) Get(ctx context.Context, id string) (*Role, error) {
+func (s *RolesService) Get(ctx context.Context, id string, params ...*QueryParams) (*Role, error) {
 	var resp struct {
 		Data Role `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "roles", Name: "get", ID: id}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(fmt.Sprintf("/roles/%s", id))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get role: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -43,29 +43,17 @@ func (s *RolesService) List(ctx context.Context, params *QueryParams) ([]Role, e
 		Data []Role `json:"data"`
 	}
 
-	req := s.client.httpClient.R().
-		SetContext(ctx).
-		SetResult(&resp)
-
-	if params != nil {
-		if len(params.Fields) > 0 {
-			req.SetQueryParam("fields", joinFields(params.Fields))
-		}
-		if params.Filter != nil {
-			req.SetQueryParam("filter", toJSONString(params.Filter))
-		}
-		if params.Limit > 0 {
-			req.SetQueryParam("limit", fmt.Sprintf("%d", params.Limit))
-		}
-	}
+	response, err := s.client.request(ctx, Operation{Service: "roles", Name: "list"}).
+		SetResult(&resp).
+		SetQueryParamsFromValues(params.Values()).
+		Get("/roles")
 
-	response, err := req.Get("/roles")
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to list roles: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return resp.Data, nil
@@ -77,8 +65,7 @@ func (s *RolesService) Create(ctx context.Context, role *Role) (*Role, error) {
 		Data Role `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "roles", Name: "create"}).
 		SetBody(role).
 		SetResult(&resp).
 		Post("/roles")
@@ -87,22 +74,41 @@ func (s *RolesService) Create(ctx context.Context, role *Role) (*Role, error) {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to create role: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
-// Update updates an existing role
+// Update updates an existing role. Fields left at their zero value are not
+// sent; use Patch to clear them.
 func (s *RolesService) Update(ctx context.Context, id string, role *Role) (*Role, error) {
+	body, err := Diff(nil, role)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, body)
+}
+
+// Patch updates the fields of a role that differ between original and
+// modified, see Diff
+func (s *RolesService) Patch(ctx context.Context, id string, original, modified *Role) (*Role, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, patch)
+}
+
+// update patches a role with body
+func (s *RolesService) update(ctx context.Context, id string, body Item) (*Role, error) {
 	var resp struct {
 		Data Role `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(role).
+	response, err := s.client.request(ctx, Operation{Service: "roles", Name: "update", ID: id}).
+		SetBody(body).
 		SetResult(&resp).
 		Patch(fmt.Sprintf("/roles/%s", id))
 
@@ -110,8 +116,8 @@ func (s *RolesService) Update(ctx context.Context, id string, role *Role) (*Role
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update role: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -119,16 +125,15 @@ func (s *RolesService) Update(ctx context.Context, id string, role *Role) (*Role
 
 // Delete deletes a role
 func (s *RolesService) Delete(ctx context.Context, id string) error {
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "roles", Name: "delete", ID: id}).
 		Delete(fmt.Sprintf("/roles/%s", id))
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != 204 {
-		return fmt.Errorf("failed to delete role: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
 	}
 
 	return nil
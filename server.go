@@ -0,0 +1,270 @@
+package directus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ServerService handles server status and specification endpoints
+type ServerService struct {
+	client *Client
+}
+
+// NewServerService creates a new server service
+func NewServerService(client *Client) *ServerService {
+	return &ServerService{client: client}
+}
+
+// Ping checks that the server is reachable
+func (s *ServerService) Ping(ctx context.Context) error {
+	response, err := s.client.request(ctx, Operation{Service: "server", Name: "ping"}).
+		Get("/server/ping")
+
+	if err != nil {
+		return err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
+	}
+
+	return nil
+}
+
+// Health retrieves the health report of the server and the services it
+// depends on. An unhealthy server answers 503 with a full report, so the
+// report is returned without error; check ServerHealth.Status.
+func (s *ServerService) Health(ctx context.Context) (*ServerHealth, error) {
+	var health ServerHealth
+
+	response, err := s.client.request(ctx, Operation{Service: "server", Name: "health"}).
+		Get("/server/health")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) && response.StatusCode() != http.StatusServiceUnavailable {
+		return nil, parseError(response)
+	}
+
+	if err := safeUnmarshal(response.Body(), &health); err != nil || health.Status == "" {
+		return nil, parseError(response)
+	}
+
+	return &health, nil
+}
+
+// Info retrieves information about the server and project. Fields that
+// require admin access, such as Version, are empty for other users.
+func (s *ServerService) Info(ctx context.Context) (*ServerInfo, error) {
+	var resp struct {
+		Data ServerInfo `json:"data"`
+	}
+
+	response, err := s.client.request(ctx, Operation{Service: "server", Name: "info"}).
+		SetResult(&resp).
+		Get("/server/info")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
+	}
+
+	return &resp.Data, nil
+}
+
+// OpenAPISpec retrieves the OpenAPI specification of the project, including
+// the collections the current user can access
+func (s *ServerService) OpenAPISpec(ctx context.Context) (json.RawMessage, error) {
+	response, err := s.client.request(ctx, Operation{Service: "server", Name: "openapi_spec"}).
+		Get("/server/specs/oas")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
+	}
+
+	if err := validateJSON(response.Body()); err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(response.Body()), nil
+}
+
+// GraphQLSpec retrieves the GraphQL SDL of the items endpoint (/graphql)
+func (s *ServerService) GraphQLSpec(ctx context.Context) (string, error) {
+	return s.graphQLSpec(ctx, "/server/specs/graphql")
+}
+
+// GraphQLSystemSpec retrieves the GraphQL SDL of the system endpoint (/graphql/system)
+func (s *ServerService) GraphQLSystemSpec(ctx context.Context) (string, error) {
+	return s.graphQLSpec(ctx, "/server/specs/graphql/system")
+}
+
+// graphQLSpec retrieves a GraphQL SDL document
+func (s *ServerService) graphQLSpec(ctx context.Context, path string) (string, error) {
+	response, err := s.client.request(ctx, Operation{Service: "server", Name: "graphql_spec"}).
+		Get(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !isSuccessStatus(response.StatusCode()) {
+		return "", parseError(response)
+	}
+
+	return string(response.Body()), nil
+}
+
+// ServerHealth represents the health report of /server/health
+type ServerHealth struct {
+	Status    string                   `json:"status"` // "ok", "warn" or "error"
+	ReleaseID string                   `json:"releaseId,omitempty"`
+	ServiceID string                   `json:"serviceId,omitempty"`
+	Checks    map[string][]HealthCheck `json:"checks,omitempty"` // Keyed like "pg:responseTime" or "storage:local:responseTime"
+}
+
+// HealthCheck represents a single check of a health report
+type HealthCheck struct {
+	ComponentType string   `json:"componentType,omitempty"`
+	ComponentID   string   `json:"componentId,omitempty"`
+	ObservedValue *float64 `json:"observedValue,omitempty"`
+	ObservedUnit  string   `json:"observedUnit,omitempty"`
+	Threshold     *float64 `json:"threshold,omitempty"`
+	Status        string   `json:"status"`
+	Output        string   `json:"output,omitempty"`
+}
+
+// OK reports whether the server is healthy. Warnings count as healthy.
+func (h *ServerHealth) OK() bool {
+	return h.Status == "ok" || h.Status == "warn"
+}
+
+// ServiceStatus returns the worst status per service, keyed by the part of
+// the check name before the first colon such as "pg", "redis" or "storage"
+func (h *ServerHealth) ServiceStatus() map[string]string {
+	severity := map[string]int{"ok": 0, "warn": 1, "error": 2}
+	statuses := make(map[string]string)
+	for name, checks := range h.Checks {
+		service, _, _ := strings.Cut(name, ":")
+		for _, check := range checks {
+			current, seen := statuses[service]
+			if !seen || severity[check.Status] > severity[current] {
+				statuses[service] = check.Status
+			}
+		}
+	}
+	return statuses
+}
+
+// ServerInfo represents the response of /server/info
+type ServerInfo struct {
+	Project         ServerProject   `json:"project"`
+	RateLimit       ServerRateLimit `json:"rateLimit"`
+	RateLimitGlobal ServerRateLimit `json:"rateLimitGlobal"`
+	QueryLimit      *QueryLimit     `json:"queryLimit,omitempty"`
+	Websocket       ServerWebsocket `json:"websocket"`
+	Version         string          `json:"version,omitempty"`
+}
+
+// ServerProject represents the public project settings in ServerInfo
+type ServerProject struct {
+	ProjectName                   string  `json:"project_name"`
+	ProjectDescriptor             *string `json:"project_descriptor,omitempty"`
+	ProjectLogo                   *string `json:"project_logo,omitempty"`
+	ProjectColor                  *string `json:"project_color,omitempty"`
+	DefaultLanguage               string  `json:"default_language,omitempty"`
+	PublicForeground              *string `json:"public_foreground,omitempty"`
+	PublicBackground              *string `json:"public_background,omitempty"`
+	PublicNote                    *string `json:"public_note,omitempty"`
+	CustomCSS                     *string `json:"custom_css,omitempty"`
+	PublicRegistration            bool    `json:"public_registration"`
+	PublicRegistrationVerifyEmail bool    `json:"public_registration_verify_email"`
+}
+
+// ServerRateLimit represents a rate limiter setting, which Directus reports
+// as false when the limiter is disabled
+type ServerRateLimit struct {
+	Enabled  bool `json:"-"`
+	Points   int  `json:"points"`
+	Duration int  `json:"duration"` // Window in seconds
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *ServerRateLimit) UnmarshalJSON(data []byte) error {
+	type plain ServerRateLimit
+	enabled, err := unmarshalFeature(data, (*plain)(r))
+	r.Enabled = enabled
+	return err
+}
+
+// QueryLimit represents the default and maximum limit of item queries
+type QueryLimit struct {
+	Default int `json:"default"`
+	Max     int `json:"max"` // -1 means unlimited
+}
+
+// ServerWebsocket represents the websocket settings, which Directus reports
+// as false when websockets are disabled
+type ServerWebsocket struct {
+	Enabled   bool              `json:"-"`
+	REST      WebsocketEndpoint `json:"rest"`
+	GraphQL   WebsocketEndpoint `json:"graphql"`
+	Heartbeat int               `json:"-"` // Heartbeat period in seconds, zero when disabled
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (w *ServerWebsocket) UnmarshalJSON(data []byte) error {
+	type plain ServerWebsocket
+	var aux struct {
+		*plain
+		Heartbeat json.RawMessage `json:"heartbeat"`
+	}
+	aux.plain = (*plain)(w)
+
+	enabled, err := unmarshalFeature(data, &aux)
+	if err != nil {
+		return err
+	}
+	w.Enabled = enabled
+	if len(aux.Heartbeat) > 0 && !bytes.Equal(aux.Heartbeat, []byte("false")) {
+		return json.Unmarshal(aux.Heartbeat, &w.Heartbeat)
+	}
+	return nil
+}
+
+// WebsocketEndpoint represents a websocket endpoint in ServerWebsocket
+type WebsocketEndpoint struct {
+	Enabled        bool   `json:"-"`
+	Authentication string `json:"authentication"`
+	Path           string `json:"path"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *WebsocketEndpoint) UnmarshalJSON(data []byte) error {
+	type plain WebsocketEndpoint
+	enabled, err := unmarshalFeature(data, (*plain)(e))
+	e.Enabled = enabled
+	return err
+}
+
+// unmarshalFeature decodes a setting that is either false or an object and
+// reports whether it is enabled
+func unmarshalFeature(data []byte, v interface{}) (bool, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("false")) || bytes.Equal(data, []byte("null")) {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
@@ -1,9 +1,6 @@
 package directus
 
-import (
-	"context"
-	"fmt"
-)
+import "context"
 
 // ServicesService handles operations on services
 type ServicesService struct {
@@ -16,23 +13,23 @@ func NewServicesService(client *Client) *ServicesService {
 }
 
 // List retrieves all services
-func (s *ServicesService) List(ctx context.Context) ([]Service, error) {
+func (s *ServicesService) List(ctx context.Context, params ...*QueryParams) ([]Service, error) {
 	var resp struct {
 		Data []Service `json:"data"`
 	}
 	path := "/services"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "services", Name: "list"}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to list services: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return resp.Data, nil
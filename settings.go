@@ -1,9 +1,6 @@
 package directus
 
-import (
-	"context"
-	"fmt"
-)
+import "context"
 
 // SettingsService handles settings operations
 type SettingsService struct {
@@ -40,36 +37,55 @@ type Settings struct {
 }
 
 // Get retrieves the system settings
-func (s *SettingsService) Get(ctx context.Context) (*Settings, error) {
+func (s *SettingsService) Get(ctx context.Context, params ...*QueryParams) (*Settings, error) {
 	var resp struct {
 		Data Settings `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "settings", Name: "get"}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get("/settings")
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get settings: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
-// Update updates the system settings
+// Update updates the system settings. Fields left at their zero value are
+// not sent; use Patch to clear them.
 func (s *SettingsService) Update(ctx context.Context, settings *Settings) (*Settings, error) {
+	body, err := Diff(nil, settings)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, body)
+}
+
+// Patch updates the settings that differ between original and modified, see
+// Diff
+func (s *SettingsService) Patch(ctx context.Context, original, modified *Settings) (*Settings, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, patch)
+}
+
+// update patches the system settings with body
+func (s *SettingsService) update(ctx context.Context, body Item) (*Settings, error) {
 	var resp struct {
 		Data Settings `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(settings).
+	response, err := s.client.request(ctx, Operation{Service: "settings", Name: "update"}).
+		SetBody(body).
 		SetResult(&resp).
 		Patch("/settings")
 
@@ -77,8 +93,8 @@ func (s *SettingsService) Update(ctx context.Context, settings *Settings) (*Sett
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update settings: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -1,9 +1,6 @@
 package directus
 
-import (
-	"context"
-	"fmt"
-)
+import "context"
 
 // SystemService handles system configuration and info operations
 type SystemService struct {
@@ -16,70 +13,81 @@ func NewSystemService(client *Client) *SystemService {
 }
 
 // GetInfo retrieves system information
+//
+// Deprecated: Directus has no /system/info endpoint. GetInfo reads
+// /server/info instead; use ServerService.Info for the full response.
 func (s *SystemService) GetInfo(ctx context.Context) (*SystemInfo, error) {
-	var resp struct {
-		Data SystemInfo `json:"data"`
-	}
-	path := "/system/info"
-
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetResult(&resp).
-		Get(path)
-
+	info, err := s.client.Server.Info(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get system info: %s", response.Status())
-	}
-
-	return &resp.Data, nil
+	return &SystemInfo{
+		ProjectName: info.Project.ProjectName,
+		Version:     info.Version,
+	}, nil
 }
 
 // GetSettings retrieves system settings
-func (s *SystemService) GetSettings(ctx context.Context) (*SystemSettings, error) {
+func (s *SystemService) GetSettings(ctx context.Context, params ...*QueryParams) (*SystemSettings, error) {
 	var resp struct {
 		Data SystemSettings `json:"data"`
 	}
 	path := "/system/settings"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "system", Name: "get_settings"}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get system settings: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
-// UpdateSettings updates system settings
+// UpdateSettings updates system settings. Fields left at their zero value
+// are not sent; use PatchSettings to clear them.
 func (s *SystemService) UpdateSettings(ctx context.Context, settings *SystemSettings) (*SystemSettings, error) {
+	body, err := Diff(nil, settings)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateSettings(ctx, body)
+}
+
+// PatchSettings updates the system settings that differ between original
+// and modified, see Diff
+func (s *SystemService) PatchSettings(ctx context.Context, original, modified *SystemSettings) (*SystemSettings, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateSettings(ctx, patch)
+}
+
+// updateSettings patches the system settings with body
+func (s *SystemService) updateSettings(ctx context.Context, body Item) (*SystemSettings, error) {
 	var resp struct {
 		Data SystemSettings `json:"data"`
 	}
-	path := "/system/settings"
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(settings).
+	response, err := s.client.request(ctx, Operation{Service: "system", Name: "update_settings"}).
+		SetBody(body).
 		SetResult(&resp).
-		Patch(path)
+		Patch("/system/settings")
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update system settings: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -0,0 +1,165 @@
+package directus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Token represents an access token and, optionally, the refresh token and expiry that came with it
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time // Zero means the token does not expire
+}
+
+// Valid reports whether the token is set and not expired
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Before(t.Expiry))
+}
+
+// expiresWithin reports whether the token expires within d
+func (t *Token) expiresWithin(d time.Duration) bool {
+	return !t.Expiry.IsZero() && time.Until(t.Expiry) <= d
+}
+
+// TokenSource supplies the access token used for a request. The client asks
+// its source for a token before every request, so implementations that do
+// network calls should cache.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// RefreshableTokenSource is a TokenSource that can replace a token the server
+// has rejected. The client calls Refresh after a 401 and retries the request
+// once with the returned token.
+type RefreshableTokenSource interface {
+	TokenSource
+	Refresh(ctx context.Context, rejected *Token) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function to the TokenSource interface
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token calls f(ctx)
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource that always returns the same token
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource{token: &Token{AccessToken: token}}
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+// ReuseTokenSource returns a TokenSource that returns t until it is about to
+// expire and then asks src for a new token. t may be nil. If src is
+// refreshable, rejected tokens are refreshed through it.
+func ReuseTokenSource(t *Token, src TokenSource) TokenSource {
+	return &reuseTokenSource{token: t, src: src, leeway: defaultRefreshLeeway}
+}
+
+type reuseTokenSource struct {
+	src    TokenSource
+	leeway time.Duration
+
+	mu    sync.Mutex
+	token *Token
+}
+
+func (s *reuseTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() && !s.token.expiresWithin(s.leeway) {
+		return s.token, nil
+	}
+
+	t, err := s.src.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = t
+	return t, nil
+}
+
+func (s *reuseTokenSource) Refresh(ctx context.Context, rejected *Token) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && rejected != nil && s.token.AccessToken != rejected.AccessToken {
+		return s.token, nil
+	}
+
+	var (
+		t   *Token
+		err error
+	)
+	if r, ok := s.src.(RefreshableTokenSource); ok {
+		t, err = r.Refresh(ctx, rejected)
+	} else {
+		t, err = s.src.Token(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.token = t
+	return t, nil
+}
+
+// ChainTokenSources returns a TokenSource that tries each source in order and
+// returns the first valid token. It fails only if every source fails.
+func ChainTokenSources(sources ...TokenSource) TokenSource {
+	return chainTokenSource(sources)
+}
+
+type chainTokenSource []TokenSource
+
+func (c chainTokenSource) Token(ctx context.Context) (*Token, error) {
+	var errs []error
+	for _, src := range c {
+		t, err := src.Token(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if t.Valid() {
+			return t, nil
+		}
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no token source returned a valid token")
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c chainTokenSource) Refresh(ctx context.Context, rejected *Token) (*Token, error) {
+	var errs []error
+	for _, src := range c {
+		r, ok := src.(RefreshableTokenSource)
+		if !ok {
+			continue
+		}
+		t, err := r.Refresh(ctx, rejected)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if t.Valid() {
+			return t, nil
+		}
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no token source can refresh the token")
+	}
+	return nil, errors.Join(errs...)
+}
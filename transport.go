@@ -0,0 +1,122 @@
+package directus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PoolConfig configures connection pooling of the default transport
+type PoolConfig struct {
+	MaxIdleConns        int           // Maximum idle connections across all hosts
+	MaxIdleConnsPerHost int           // Maximum idle connections per host
+	MaxConnsPerHost     int           // Maximum connections per host, zero means unlimited
+	IdleConnTimeout     time.Duration // How long an idle connection is kept
+	KeepAlive           time.Duration // TCP keep-alive period
+}
+
+// transportOptions reports whether config customizes the transport itself
+func transportOptions(config Config) bool {
+	return config.TLSConfig != nil || config.RootCAs != nil || len(config.Certificates) > 0 ||
+		config.ProxyURL != "" || config.Pool != (PoolConfig{})
+}
+
+// newHTTPClient returns the *http.Client the SDK sends requests through,
+// built from config without modifying a client or transport passed in it
+func newHTTPClient(config Config) (*http.Client, error) {
+	hc := &http.Client{}
+	if config.HTTPClient != nil {
+		copied := *config.HTTPClient
+		hc = &copied
+	}
+	if config.Timeout > 0 {
+		hc.Timeout = config.Timeout
+	}
+
+	base := config.Transport
+	if base == nil {
+		base = hc.Transport
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if transportOptions(config) {
+		t, ok := base.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("TLS, proxy and pool options require an *http.Transport, got %T", base)
+		}
+		t, err := configureTransport(t.Clone(), config)
+		if err != nil {
+			return nil, err
+		}
+		base = t
+	}
+
+	hc.Transport = base
+	return hc, nil
+}
+
+// configureTransport applies the TLS, proxy and pool options of config to t
+func configureTransport(t *http.Transport, config Config) (*http.Transport, error) {
+	if config.TLSConfig != nil || config.RootCAs != nil || len(config.Certificates) > 0 {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if config.TLSConfig != nil {
+			tlsConfig = config.TLSConfig.Clone()
+		} else if t.TLSClientConfig != nil {
+			tlsConfig = t.TLSClientConfig.Clone()
+		}
+		if config.RootCAs != nil {
+			tlsConfig.RootCAs = config.RootCAs
+		}
+		if len(config.Certificates) > 0 {
+			tlsConfig.Certificates = append(tlsConfig.Certificates, config.Certificates...)
+		}
+		t.TLSClientConfig = tlsConfig
+	}
+
+	if config.ProxyURL != "" {
+		proxy, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+
+	pool := config.Pool
+	if pool.MaxIdleConns > 0 {
+		t.MaxIdleConns = pool.MaxIdleConns
+	}
+	if pool.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+	}
+	if pool.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = pool.MaxConnsPerHost
+	}
+	if pool.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = pool.IdleConnTimeout
+	}
+	if pool.KeepAlive > 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: pool.KeepAlive}
+		t.DialContext = dialer.DialContext
+	}
+
+	return t, nil
+}
+
+// LoadRootCAs returns a certificate pool containing the system roots and the
+// PEM encoded certificates in pem, for servers signed by an internal CA
+func LoadRootCAs(pem []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in PEM data")
+	}
+	return pool, nil
+}
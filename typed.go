@@ -0,0 +1,247 @@
+package directus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// TypedItems provides item operations on a collection that decode straight
+// into T through its json tags
+type TypedItems[T any] struct {
+	items      *ItemsService
+	collection string
+}
+
+// NewTypedItems creates a typed view of a collection
+func NewTypedItems[T any](client *Client, collection string) *TypedItems[T] {
+	return &TypedItems[T]{items: client.Items, collection: collection}
+}
+
+// Collection returns the name of the collection
+func (t *TypedItems[T]) Collection() string {
+	return t.collection
+}
+
+// Get retrieves a single item by primary key
+func (t *TypedItems[T]) Get(ctx context.Context, id interface{}, params *QueryParams) (*T, error) {
+	key, err := formatID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data *T `json:"data"`
+	}
+	if err := t.items.get(ctx, t.collection, key, params, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Data == nil {
+		return nil, fmt.Errorf("no data returned")
+	}
+
+	return resp.Data, nil
+}
+
+// List retrieves multiple items
+func (t *TypedItems[T]) List(ctx context.Context, params *QueryParams) ([]T, *Meta, error) {
+	var resp struct {
+		Data []T   `json:"data"`
+		Meta *Meta `json:"meta,omitempty"`
+	}
+	if err := t.items.list(ctx, t.collection, params, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Data, resp.Meta, nil
+}
+
+// Create creates a new item and returns it as stored
+func (t *TypedItems[T]) Create(ctx context.Context, item *T) (*T, error) {
+	var resp struct {
+		Data *T `json:"data"`
+	}
+	if err := t.items.create(ctx, t.collection, item, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Update updates an existing item. Fields left at their zero value are not
+// sent; use Patch to clear them.
+func (t *TypedItems[T]) Update(ctx context.Context, id interface{}, item *T) (*T, error) {
+	key, err := formatID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := Diff(nil, item)
+	if err != nil {
+		return nil, err
+	}
+	return t.update(ctx, key, body)
+}
+
+// Patch updates the fields of an item that differ between original and
+// modified, see Diff
+func (t *TypedItems[T]) Patch(ctx context.Context, id interface{}, original, modified *T) (*T, error) {
+	key, err := formatID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return t.update(ctx, key, patch)
+}
+
+// update patches an item with body
+func (t *TypedItems[T]) update(ctx context.Context, key string, body Item) (*T, error) {
+	var resp struct {
+		Data *T `json:"data"`
+	}
+	if err := t.items.update(ctx, t.collection, key, body, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Delete deletes an item by primary key
+func (t *TypedItems[T]) Delete(ctx context.Context, id interface{}) error {
+	key, err := formatID(id)
+	if err != nil {
+		return err
+	}
+
+	return t.items.Delete(ctx, t.collection, key)
+}
+
+// Ref is a relational field that holds either the primary key of the related
+// item or, when the relation is expanded through Fields, the item itself
+type Ref[T any] struct {
+	id   interface{}
+	Item *T
+}
+
+// RefID returns a Ref holding the primary key of a related item
+func RefID[T any](id interface{}) Ref[T] {
+	return Ref[T]{id: id}
+}
+
+// RefItem returns a Ref holding a related item, e.g. for nested creates
+func RefItem[T any](item *T) Ref[T] {
+	return Ref[T]{Item: item}
+}
+
+// ID returns the primary key of the related item. For expanded relations it
+// is read from the item's "id" field.
+func (r Ref[T]) ID() string {
+	key, _ := formatID(r.id)
+	return key
+}
+
+// Int64 returns the primary key of the related item as an integer
+func (r Ref[T]) Int64() (int64, error) {
+	return strconv.ParseInt(r.ID(), 10, 64)
+}
+
+// IsSet reports whether the relation holds a key or an item
+func (r Ref[T]) IsSet() bool {
+	return r.id != nil || r.Item != nil
+}
+
+// Expanded reports whether the relation holds the related item
+func (r Ref[T]) Expanded() bool {
+	return r.Item != nil
+}
+
+// relatedType returns the type of the related item
+func (r Ref[T]) relatedType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *Ref[T]) UnmarshalJSON(data []byte) error {
+	*r = Ref[T]{}
+
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var item T
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		r.Item = &item
+
+		var key struct {
+			ID interface{} `json:"id"`
+		}
+		if err := unmarshalNumber(data, &key); err == nil {
+			r.id = key.ID
+		}
+		return nil
+	}
+
+	return unmarshalNumber(data, &r.id)
+}
+
+// MarshalJSON implements json.Marshaler
+func (r Ref[T]) MarshalJSON() ([]byte, error) {
+	if r.Item != nil {
+		return json.Marshal(r.Item)
+	}
+	return json.Marshal(r.id)
+}
+
+// unmarshalNumber decodes JSON keeping numbers as json.Number
+func unmarshalNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// formatID formats a primary key for use in a request path. Whole float64
+// values, which is how numbers decode into Item, are formatted without a
+// fraction or exponent.
+func formatID(id interface{}) (string, error) {
+	switch v := id.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		if v != float64(int64(v)) {
+			return "", fmt.Errorf("invalid primary key %v: not a whole number", v)
+		}
+		return strconv.FormatInt(int64(v), 10), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported primary key type %T", id)
+	}
+}
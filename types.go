@@ -172,14 +172,39 @@ type QueryParams struct {
 	Filter  map[string]interface{} `json:"filter,omitempty"`
 	Search  string                 `json:"search,omitempty"`
 	Sort    []string               `json:"sort,omitempty"`
-	Limit   int                    `json:"limit,omitempty"`
+	Limit   int                    `json:"limit,omitempty"` // -1 returns all items
 	Offset  int                    `json:"offset,omitempty"`
 	Page    int                    `json:"page,omitempty"`
 	Deep    map[string]interface{} `json:"deep,omitempty"`
-	Export  string                 `json:"export,omitempty"`
-	Lang    string                 `json:"lang,omitempty"` // Language code for translations
+	Export  string                 `json:"export,omitempty"` // "json", "csv", "xml" or "yaml" to download the result as a file
+	Lang    string                 `json:"lang,omitempty"`   // Language code for translations
+	Meta    []string               `json:"meta,omitempty"`   // Metadata to return: "filter_count", "total_count" or "*"
+
+	Aggregate Aggregate `json:"aggregate,omitempty"` // Aggregate functions to compute instead of returning items
+	GroupBy   []string  `json:"groupBy,omitempty"`   // Fields to group aggregates by
+	Version   string    `json:"version,omitempty"`   // Content version to read instead of the main item
+	Backlink  *bool     `json:"backlink,omitempty"`  // Whether "*.*" fields include the relation back to the parent. Directus defaults to true
 }
 
+// AggregateFunc represents Directus aggregate functions
+type AggregateFunc string
+
+const (
+	AggregateCount         AggregateFunc = "count"
+	AggregateCountDistinct AggregateFunc = "countDistinct"
+	AggregateCountAll      AggregateFunc = "countAll"
+	AggregateSum           AggregateFunc = "sum"
+	AggregateSumDistinct   AggregateFunc = "sumDistinct"
+	AggregateAvg           AggregateFunc = "avg"
+	AggregateAvgDistinct   AggregateFunc = "avgDistinct"
+	AggregateMin           AggregateFunc = "min"
+	AggregateMax           AggregateFunc = "max"
+)
+
+// Aggregate maps aggregate functions to the fields they apply to. Count and
+// CountAll without fields count all items.
+type Aggregate map[AggregateFunc][]string
+
 // FilterOperator represents Directus filter operators
 type FilterOperator string
 
@@ -201,6 +226,23 @@ const (
 	FilterNotEmpty      FilterOperator = "_nempty"
 	FilterNull          FilterOperator = "_null"
 	FilterNotNull       FilterOperator = "_nnull"
+
+	FilterIContains         FilterOperator = "_icontains"
+	FilterNotIContains      FilterOperator = "_nicontains"
+	FilterNotStartsWith     FilterOperator = "_nstarts_with"
+	FilterIStartsWith       FilterOperator = "_istarts_with"
+	FilterNotIStartsWith    FilterOperator = "_nistarts_with"
+	FilterNotEndsWith       FilterOperator = "_nends_with"
+	FilterIEndsWith         FilterOperator = "_iends_with"
+	FilterNotIEndsWith      FilterOperator = "_niends_with"
+	FilterNotBetween        FilterOperator = "_nbetween"
+	FilterRegex             FilterOperator = "_regex"
+	FilterIntersects        FilterOperator = "_intersects"
+	FilterNotIntersects     FilterOperator = "_nintersects"
+	FilterIntersectsBBox    FilterOperator = "_intersects_bbox"
+	FilterNotIntersectsBBox FilterOperator = "_nintersects_bbox"
+	FilterSome              FilterOperator = "_some" // Some related items of a one-to-many field match
+	FilterNone              FilterOperator = "_none" // No related items of a one-to-many field match
 )
 
 // FilterCondition represents a single filter condition
@@ -16,22 +16,22 @@ func NewUsersService(client *Client) *UsersService {
 }
 
 // Get retrieves a user by ID
-func (s *UsersService) Get(ctx context.Context, id string) (*User, error) {
+func (s *UsersService) Get(ctx context.Context, id string, params ...*QueryParams) (*User, error) {
 	var resp struct {
 		Data User `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "users", Name: "get", ID: id}).
 		SetResult(&resp).
+		SetQueryParamsFromValues(optionalParams(params).Values()).
 		Get(fmt.Sprintf("/users/%s", id))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get user: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -43,35 +43,17 @@ func (s *UsersService) List(ctx context.Context, params *QueryParams) ([]User, e
 		Data []User `json:"data"`
 	}
 
-	req := s.client.httpClient.R().
-		SetContext(ctx).
-		SetResult(&resp)
-
-	if params != nil {
-		if len(params.Fields) > 0 {
-			req.SetQueryParam("fields", joinFields(params.Fields))
-		}
-		if params.Filter != nil {
-			req.SetQueryParam("filter", toJSONString(params.Filter))
-		}
-		if params.Limit > 0 {
-			req.SetQueryParam("limit", fmt.Sprintf("%d", params.Limit))
-		}
-		if params.Offset > 0 {
-			req.SetQueryParam("offset", fmt.Sprintf("%d", params.Offset))
-		}
-		if len(params.Sort) > 0 {
-			req.SetQueryParam("sort", joinFields(params.Sort))
-		}
-	}
-
-	response, err := req.Get("/users")
+	response, err := s.client.request(ctx, Operation{Service: "users", Name: "list"}).
+		SetResult(&resp).
+		SetQueryParamsFromValues(params.Values()).
+		Get("/users")
+
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to list users: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return resp.Data, nil
@@ -83,8 +65,7 @@ func (s *UsersService) Create(ctx context.Context, user *User) (*User, error) {
 		Data User `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "users", Name: "create"}).
 		SetBody(user).
 		SetResult(&resp).
 		Post("/users")
@@ -93,22 +74,41 @@ func (s *UsersService) Create(ctx context.Context, user *User) (*User, error) {
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to create user: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
 }
 
-// Update updates an existing user
+// Update updates an existing user. Fields left at their zero value are not
+// sent; use Patch to clear them.
 func (s *UsersService) Update(ctx context.Context, id string, user *User) (*User, error) {
+	body, err := Diff(nil, user)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, body)
+}
+
+// Patch updates the fields of a user that differ between original and
+// modified, see Diff
+func (s *UsersService) Patch(ctx context.Context, id string, original, modified *User) (*User, error) {
+	patch, err := Diff(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, patch)
+}
+
+// update patches a user with body
+func (s *UsersService) update(ctx context.Context, id string, body Item) (*User, error) {
 	var resp struct {
 		Data User `json:"data"`
 	}
 
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
-		SetBody(user).
+	response, err := s.client.request(ctx, Operation{Service: "users", Name: "update", ID: id}).
+		SetBody(body).
 		SetResult(&resp).
 		Patch(fmt.Sprintf("/users/%s", id))
 
@@ -116,8 +116,8 @@ func (s *UsersService) Update(ctx context.Context, id string, user *User) (*User
 		return nil, err
 	}
 
-	if response.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to update user: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return nil, parseError(response)
 	}
 
 	return &resp.Data, nil
@@ -125,16 +125,15 @@ func (s *UsersService) Update(ctx context.Context, id string, user *User) (*User
 
 // Delete deletes a user
 func (s *UsersService) Delete(ctx context.Context, id string) error {
-	response, err := s.client.httpClient.R().
-		SetContext(ctx).
+	response, err := s.client.request(ctx, Operation{Service: "users", Name: "delete", ID: id}).
 		Delete(fmt.Sprintf("/users/%s", id))
 
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode() != 204 {
-		return fmt.Errorf("failed to delete user: %s", response.Status())
+	if !isSuccessStatus(response.StatusCode()) {
+		return parseError(response)
 	}
 
 	return nil
@@ -22,33 +22,28 @@ func toJSONString(data interface{}) string {
 	return string(b)
 }
 
-// parseError parses an error response from the API with improved JSON handling
+// parseError builds an *APIError from an error response
 func parseError(resp *resty.Response) error {
-	var errResp ErrorResponse
-
-	// Use safeUnmarshal for better error handling
-	if err := safeUnmarshal(resp.Body(), &errResp); err != nil {
-		// If JSON parsing fails, try to extract error message from response body
-		body := string(resp.Body())
-		if body != "" {
-			return fmt.Errorf("API request failed with status %d: %s (JSON parse error: %v)",
-				resp.StatusCode(), body, err)
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode(),
+	}
+	if req := resp.Request; req != nil {
+		apiErr.Method = req.Method
+		if req.RawRequest != nil {
+			apiErr.Path = req.RawRequest.URL.Path
 		}
-		return fmt.Errorf("API request failed with status %d: %v", resp.StatusCode(), err)
 	}
 
-	if len(errResp.Errors) > 0 {
-		// Include extension details if available
-		errorMsg := errResp.Errors[0].Message
-		if extensions := errResp.Errors[0].Extensions; extensions != nil {
-			if code, ok := extensions["code"].(string); ok {
-				errorMsg = fmt.Sprintf("%s (code: %s)", errorMsg, code)
-			}
-		}
-		return fmt.Errorf("API error: %s", errorMsg)
+	var errResp ErrorResponse
+	if err := safeUnmarshal(resp.Body(), &errResp); err != nil || len(errResp.Errors) == 0 {
+		// Not a Directus error payload, keep the raw body for context
+		apiErr.Body = strings.TrimSpace(string(resp.Body()))
+		return apiErr
 	}
 
-	return fmt.Errorf("API request failed with status %d", resp.StatusCode())
+	apiErr.Errors = errResp.Errors
+	apiErr.Code = errResp.Errors[0].Code()
+	return apiErr
 }
 
 // parseResponse safely parses API response with improved error handling
@@ -71,12 +66,12 @@ func isSuccessStatus(code int) bool {
 
 // NewFilterEqual creates an equality filter condition
 func NewFilterEqual(field string, value interface{}) map[string]interface{} {
-	return map[string]interface{}{field: map[string]interface{}{string(FilterEqual): value}}
+	return map[string]interface{}{field: map[string]interface{}{string(FilterEqual): normalizeOperand(value)}}
 }
 
 // NewFilterNotEqual creates a not-equal filter condition
 func NewFilterNotEqual(field string, value interface{}) map[string]interface{} {
-	return map[string]interface{}{field: map[string]interface{}{string(FilterNotEqual): value}}
+	return map[string]interface{}{field: map[string]interface{}{string(FilterNotEqual): normalizeOperand(value)}}
 }
 
 // NewFilterContains creates a contains filter condition
@@ -86,12 +81,13 @@ func NewFilterContains(field string, value string) map[string]interface{} {
 
 // NewFilterIn creates an "in" filter condition
 func NewFilterIn(field string, values []interface{}) map[string]interface{} {
-	return map[string]interface{}{field: map[string]interface{}{string(FilterIn): values}}
+	return map[string]interface{}{field: map[string]interface{}{string(FilterIn): normalizeOperand(values)}}
 }
 
-// NewFilterBetween creates a between filter condition
+// NewFilterBetween creates a between filter condition. Times are sent as
+// timestamps.
 func NewFilterBetween(field string, from, to interface{}) map[string]interface{} {
-	return map[string]interface{}{field: map[string]interface{}{string(FilterBetween): []interface{}{from, to}}}
+	return map[string]interface{}{field: map[string]interface{}{string(FilterBetween): normalizeOperand([]interface{}{from, to})}}
 }
 
 // NewFilterNull creates a null filter condition
@@ -0,0 +1,145 @@
+package directus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Variable is a dynamic variable that Directus resolves when it applies a
+// filter, usable wherever a filter takes a value
+type Variable string
+
+const (
+	VarNow             Variable = "$NOW"              // The current time
+	VarCurrentUser     Variable = "$CURRENT_USER"     // Primary key of the current user
+	VarCurrentRole     Variable = "$CURRENT_ROLE"     // Primary key of the current user's role
+	VarCurrentPolicies Variable = "$CURRENT_POLICIES" // Primary keys of the current user's policies
+)
+
+// TimeUnit is a unit of a $NOW offset
+type TimeUnit string
+
+const (
+	Years   TimeUnit = "years"
+	Months  TimeUnit = "months"
+	Weeks   TimeUnit = "weeks"
+	Days    TimeUnit = "days"
+	Hours   TimeUnit = "hours"
+	Minutes TimeUnit = "minutes"
+	Seconds TimeUnit = "seconds"
+)
+
+// NowOffset returns $NOW moved by n units, like $NOW(-7 days) for
+// NowOffset(-7, Days)
+func NowOffset(n int, unit TimeUnit) Variable {
+	return Variable(fmt.Sprintf("$NOW(%+d %s)", n, unit))
+}
+
+// CurrentUserField returns a field of the current user, like
+// $CURRENT_USER.email or $CURRENT_USER.role.name
+func CurrentUserField(path string) Variable {
+	return Variable(string(VarCurrentUser) + "." + path)
+}
+
+// CurrentRoleField returns a field of the current user's role, like
+// $CURRENT_ROLE.name
+func CurrentRoleField(path string) Variable {
+	return Variable(string(VarCurrentRole) + "." + path)
+}
+
+// Layouts of the date and time field types of Directus
+const (
+	DateLayout      = "2006-01-02"
+	TimeLayout      = "15:04:05"
+	DateTimeLayout  = "2006-01-02T15:04:05"
+	TimestampLayout = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// FormatDate formats t for a date field, taking the day in loc. A nil loc
+// means UTC.
+func FormatDate(t time.Time, loc *time.Location) string {
+	return inLocation(t, loc).Format(DateLayout)
+}
+
+// FormatTime formats t for a time field, in loc. A nil loc means UTC.
+func FormatTime(t time.Time, loc *time.Location) string {
+	return inLocation(t, loc).Format(TimeLayout)
+}
+
+// FormatDateTime formats t for a dateTime field. Directus stores dateTime
+// values without a time zone, so pass the time zone of the project. A nil
+// loc means UTC.
+func FormatDateTime(t time.Time, loc *time.Location) string {
+	return inLocation(t, loc).Format(DateTimeLayout)
+}
+
+// FormatTimestamp formats t for a timestamp field, in UTC with milliseconds
+// like Directus returns them
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(TimestampLayout)
+}
+
+// inLocation returns t in loc, or in UTC when loc is nil
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc)
+}
+
+// Year returns the function field extracting the year of a date field,
+// for use in Fields, Filter, Sort and GroupBy. Fields of related items are
+// separated by dots, like "author.birthday".
+func Year(field string) string {
+	return functionCall("year", field)
+}
+
+// Month returns the function field extracting the month of a date field
+func Month(field string) string {
+	return functionCall("month", field)
+}
+
+// Week returns the function field extracting the week of a date field
+func Week(field string) string {
+	return functionCall("week", field)
+}
+
+// Day returns the function field extracting the day of the month of a date field
+func Day(field string) string {
+	return functionCall("day", field)
+}
+
+// Weekday returns the function field extracting the day of the week of a
+// date field, with Sunday as 0
+func Weekday(field string) string {
+	return functionCall("weekday", field)
+}
+
+// Hour returns the function field extracting the hour of a time field
+func Hour(field string) string {
+	return functionCall("hour", field)
+}
+
+// Minute returns the function field extracting the minute of a time field
+func Minute(field string) string {
+	return functionCall("minute", field)
+}
+
+// Second returns the function field extracting the second of a time field
+func Second(field string) string {
+	return functionCall("second", field)
+}
+
+// Count returns the function field counting the related items of a
+// one-to-many field, or the elements of a JSON array field
+func Count(field string) string {
+	return functionCall("count", field)
+}
+
+// functionCall applies a function to the last segment of a field path, the
+// form Directus expects, like author.year(birthday)
+func functionCall(fn, field string) string {
+	i := strings.LastIndexByte(field, '.')
+	return fmt.Sprintf("%s%s(%s)", field[:i+1], fn, field[i+1:])
+}